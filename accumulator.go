@@ -0,0 +1,46 @@
+package errorx
+
+// Accumulator collects errors, say, across the attempts of a retry loop, and keeps a single most significant of them.
+// Significance is defined by a ranking of traits: an error with a trait which comes earlier in the ranking wins.
+// An error with none of the ranked traits is the least significant, and of equally significant errors the first one wins.
+// Unlike MultiError, all the other errors are dropped. Accumulator is not safe for concurrent use.
+type Accumulator struct {
+	traitPriority []Trait
+	result        error
+	rank          int
+}
+
+// NewAccumulator creates an Accumulator with a ranking of traits, from the most significant to the least one.
+func NewAccumulator(traitPriority ...Trait) *Accumulator {
+	return &Accumulator{
+		traitPriority: traitPriority,
+	}
+}
+
+// Add takes an error into account, nil errors are ignored.
+func (a *Accumulator) Add(err error) {
+	if err == nil {
+		return
+	}
+
+	rank := a.rankOf(err)
+	if a.result == nil || rank < a.rank {
+		a.result = err
+		a.rank = rank
+	}
+}
+
+// Result returns the most significant error of all added, or nil if there were none.
+func (a *Accumulator) Result() error {
+	return a.result
+}
+
+func (a *Accumulator) rankOf(err error) int {
+	for i, trait := range a.traitPriority {
+		if HasTrait(err, trait) {
+			return i
+		}
+	}
+
+	return len(a.traitPriority)
+}
@@ -0,0 +1,67 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccumulator(t *testing.T) {
+	fatal := RegisterTrait("fatal")
+	namespace := NewNamespace("accumulator")
+	fatalError := namespace.NewType("fatal", fatal)
+	testTypeTemporary := namespace.NewType("temporary", Temporary())
+
+	t.Run("Empty", func(t *testing.T) {
+		acc := NewAccumulator(fatal, Temporary())
+		require.Nil(t, acc.Result())
+
+		acc.Add(nil)
+		require.Nil(t, acc.Result())
+	})
+
+	t.Run("FatalAfterRetryable", func(t *testing.T) {
+		retryable := testTypeTemporary.New("first attempt")
+		fatalErr := fatalError.New("second attempt")
+
+		acc := NewAccumulator(fatal, Temporary())
+		acc.Add(retryable)
+		acc.Add(fatalErr)
+		acc.Add(testTypeTemporary.New("third attempt"))
+		acc.Add(nil)
+
+		require.Equal(t, fatalErr, acc.Result())
+	})
+
+	t.Run("FirstOfEqualWins", func(t *testing.T) {
+		first := testTypeTemporary.New("first attempt")
+
+		acc := NewAccumulator(fatal, Temporary())
+		acc.Add(first)
+		acc.Add(testTypeTemporary.New("second attempt"))
+
+		require.Equal(t, first, acc.Result())
+	})
+
+	t.Run("UnrankedIsLeastSignificant", func(t *testing.T) {
+		unranked := errors.New("unranked")
+		retryable := Decorate(testTypeTemporary.New("retryable"), "decorated")
+
+		acc := NewAccumulator(fatal, Temporary())
+		acc.Add(unranked)
+		require.Equal(t, unranked, acc.Result())
+
+		acc.Add(retryable)
+		require.Equal(t, retryable, acc.Result())
+	})
+
+	t.Run("NoRanking", func(t *testing.T) {
+		first := errors.New("first")
+
+		acc := NewAccumulator()
+		acc.Add(first)
+		acc.Add(fatalError.New("second"))
+		require.Equal(t, first, acc.Result())
+	})
+}
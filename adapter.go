@@ -0,0 +1,78 @@
+package errorx
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// ErrorAdapter recognises traits and dynamic properties of a non-errorx error, see RegisterErrorAdapter.
+// It returns false for an error it does not know.
+type ErrorAdapter func(err error) (traits []Trait, properties map[Property]interface{}, ok bool)
+
+// RegisterErrorAdapter makes errors of a third-party library possess errorx traits and properties, with no need to wrap them.
+// For example, an adapter may recognise a database driver error and expose its error code as a property, along with a Duplicate() trait.
+// Adapters are consulted for a non-errorx error, either checked directly or revealed by a transparent wrap:
+// by HasTrait() and IsTrait(), by Property() and ExtractProperty(), and by Adopt(), which attaches the recognised properties to an error.
+// Adapters are tried in the order of registration, and the first one to recognise an error is used.
+// Registration is meant to be performed at initialization, but it is safe to do concurrently; see also SnapshotRegistry.
+func RegisterErrorAdapter(adapter ErrorAdapter) {
+	errorAdapters.mu.Lock()
+	defer errorAdapters.mu.Unlock()
+
+	adapters, _ := errorAdapters.adapters.Load().([]ErrorAdapter)
+	errorAdapters.adapters.Store(append(adapters[:len(adapters):len(adapters)], adapter))
+}
+
+var errorAdapters = struct {
+	mu       sync.Mutex
+	adapters atomic.Value // []ErrorAdapter
+}{}
+
+func snapshotErrorAdapters() func() {
+	errorAdapters.mu.Lock()
+	defer errorAdapters.mu.Unlock()
+
+	adapters, _ := errorAdapters.adapters.Load().([]ErrorAdapter)
+	return func() {
+		errorAdapters.mu.Lock()
+		defer errorAdapters.mu.Unlock()
+
+		errorAdapters.adapters.Store(adapters)
+	}
+}
+
+func adaptError(err error) ([]Trait, map[Property]interface{}, bool) {
+	adapters, _ := errorAdapters.adapters.Load().([]ErrorAdapter)
+	for _, adapter := range adapters {
+		if traits, properties, ok := adapter(err); ok {
+			return traits, properties, true
+		}
+	}
+
+	return nil, nil, false
+}
+
+func hasAdaptedTrait(err error, key Trait) bool {
+	traits, _, ok := adaptError(err)
+	if !ok {
+		return false
+	}
+
+	for _, trait := range traits {
+		if trait == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+func adaptedProperty(err error, key Property) (interface{}, bool) {
+	_, properties, ok := adaptError(err)
+	if !ok {
+		return nil, false
+	}
+
+	value, ok := properties[key]
+	return value, ok
+}
@@ -0,0 +1,105 @@
+package errorx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// pgError imitates an error of a third-party database driver
+type pgError struct {
+	code       string
+	constraint string
+}
+
+func (e *pgError) Error() string {
+	return "pg: error " + e.code
+}
+
+var testPropertySQLState = RegisterPrintableProperty("sqlstate")
+
+func pgErrorAdapter(err error) ([]Trait, map[Property]interface{}, bool) {
+	pgErr, ok := err.(*pgError)
+	if !ok {
+		return nil, nil, false
+	}
+
+	properties := map[Property]interface{}{testPropertySQLState: pgErr.code}
+	if pgErr.code != "23505" {
+		return nil, properties, true
+	}
+
+	properties[propertyConstraint] = pgErr.constraint
+	return []Trait{Duplicate()}, properties, true
+}
+
+func TestRegisterErrorAdapter(t *testing.T) {
+	defer SnapshotRegistry()()
+	RegisterErrorAdapter(pgErrorAdapter)
+
+	duplicate := &pgError{code: "23505", constraint: "users_email_key"}
+	other := &pgError{code: "40001"}
+
+	t.Run("Plain", func(t *testing.T) {
+		require.True(t, HasTrait(duplicate, Duplicate()))
+		require.True(t, IsDuplicate(duplicate))
+		require.False(t, HasTrait(duplicate, NotFound()))
+		require.False(t, IsDuplicate(other))
+
+		code, ok := ExtractProperty(other, testPropertySQLState)
+		require.True(t, ok)
+		require.Equal(t, "40001", code)
+
+		constraint, ok := Constraint(duplicate)
+		require.True(t, ok)
+		require.Equal(t, "users_email_key", constraint)
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		err := Decorate(Decorate(duplicate, "failed to insert"), "failed to sign up")
+		require.True(t, IsDuplicate(err))
+
+		code, ok := err.Property(testPropertySQLState)
+		require.True(t, ok)
+		require.Equal(t, "23505", code)
+	})
+
+	t.Run("Opaque", func(t *testing.T) {
+		err := InternalError.Wrap(duplicate, "wrapped")
+		require.False(t, IsDuplicate(err))
+
+		_, ok := err.Property(testPropertySQLState)
+		require.False(t, ok)
+	})
+
+	t.Run("Adopt", func(t *testing.T) {
+		err := Adopt(duplicate)
+		require.True(t, IsDuplicate(err))
+		require.Equal(t, "{constraint: users_email_key, sqlstate: 23505}, cause: pg: error 23505", err.Error())
+
+		code, ok := err.OwnProperty(testPropertySQLState)
+		require.True(t, ok)
+		require.Equal(t, "23505", code)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		err := fmt.Errorf("test")
+		require.False(t, HasTrait(err, Duplicate()))
+
+		_, ok := ExtractProperty(err, testPropertySQLState)
+		require.False(t, ok)
+		require.Equal(t, "test", Adopt(err).Error())
+	})
+}
+
+func TestRegisterErrorAdapterSnapshot(t *testing.T) {
+	err := &pgError{code: "23505"}
+
+	restore := SnapshotRegistry()
+	RegisterErrorAdapter(pgErrorAdapter)
+	require.True(t, IsDuplicate(err))
+
+	restore()
+	require.False(t, IsDuplicate(err))
+}
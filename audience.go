@@ -0,0 +1,45 @@
+package errorx
+
+// AudienceDev is an audience of developers, to which all the properties are visible, see FormatFor.
+const AudienceDev = "dev"
+
+// PropertyOption is an option of a property registration, see RegisterProperty.
+type PropertyOption func(*property)
+
+// VisibleTo restricts a property to be rendered by FormatFor only for the listed audiences, say, "ops", and for AudienceDev.
+// Such a property is rendered for these audiences even if it is not printable.
+// A property without this option is rendered for any audience if it is printable, just as it is in Error().
+// Note that this only affects FormatFor, and not the other outputs.
+func VisibleTo(audiences ...string) PropertyOption {
+	return func(p *property) {
+		p.audiences = append(p.audiences, audiences...)
+	}
+}
+
+// FormatFor returns a one-line representation of an error, such as Error(), for a specific audience.
+// Properties registered with VisibleTo() are only included if they are visible to the audience.
+// AudienceDev sees all such properties. For an outside observer, such as an API client, consider Public() instead.
+func (e *Error) FormatFor(audience string) string {
+	if e == nil {
+		return ""
+	}
+	return e.formattedMessage(messageFormat{
+		causeDepth:     -1,
+		causeSeparator: causeSeparator(),
+		audience:       audience,
+	})
+}
+
+func (p *property) isVisibleTo(audience string) bool {
+	if audience == AudienceDev {
+		return true
+	}
+
+	for _, a := range p.audiences {
+		if a == audience {
+			return true
+		}
+	}
+
+	return false
+}
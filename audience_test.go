@@ -0,0 +1,25 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatFor(t *testing.T) {
+	opsOnly := RegisterProperty("host", VisibleTo("ops"))
+	opsAndClient := RegisterPrintableProperty("requestID", VisibleTo("ops", "client"))
+
+	err := Decorate(testType.Wrap(errors.New("root"), "failed").
+		WithProperty(opsOnly, "db-1").
+		WithProperty(opsAndClient, "r-42").
+		WithProperty(testInfoProperty2, 2).
+		WithProperty(testProperty0, 0), "decorated")
+
+	require.Equal(t, "decorated, cause: foo.bar: failed {prop2: 2, requestID: r-42}, cause: root", err.Error())
+	require.Equal(t, "decorated, cause: foo.bar: failed {prop2: 2, requestID: r-42}, cause: root", err.FormatFor("client"))
+	require.Equal(t, "decorated, cause: foo.bar: failed {prop2: 2, requestID: r-42, host: db-1}, cause: root", err.FormatFor("ops"))
+	require.Equal(t, "decorated, cause: foo.bar: failed {prop2: 2, requestID: r-42, host: db-1}, cause: root", err.FormatFor(AudienceDev))
+	require.Equal(t, "decorated, cause: foo.bar: failed {prop2: 2}, cause: root", err.FormatFor("other"))
+}
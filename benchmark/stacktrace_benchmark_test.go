@@ -4,7 +4,10 @@ import (
 	"errors"
 	"fmt"
 	"runtime/debug"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/joomcode/errorx"
 )
@@ -96,6 +99,28 @@ func BenchmarkStackTraceNaiveErrorPrint100(b *testing.B) {
 	consumeResult(errorSink)
 }
 
+// BenchmarkErrorxErrorTypeNameLength shows that the memory footprint of an error does not depend on a length of its type name,
+// as an error only holds a pointer to its type, and a full name of a type is computed once upon its declaration.
+func BenchmarkErrorxErrorTypeNameLength(b *testing.B) {
+	types := []struct {
+		name      string
+		errorType *errorx.Type
+	}{
+		{"Short", NoStackTraceError},
+		{"Long", LongNameNoStackTraceError},
+	}
+
+	for _, tt := range types {
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				errorSink = tt.errorType.New("benchmark")
+			}
+			consumeResult(errorSink)
+		})
+	}
+}
+
 func createSimpleError() error {
 	return errors.New("benchmark")
 }
@@ -104,6 +129,9 @@ var (
 	Errors            = errorx.NewNamespace("errorx.benchmark")
 	NoStackTraceError = Errors.NewType("no_stack_trace").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
 	StackTraceError   = Errors.NewType("stack_trace")
+
+	LongNameErrors            = errorx.NewNamespace("errorx.benchmark.a_namespace_with_a_rather_long_name_of_its_own")
+	LongNameNoStackTraceError = LongNameErrors.NewType("a_type_with_an_even_longer_name_as_is_common_in_a_large_taxonomy").ApplyModifiers(errorx.TypeModifierOmitStackTrace)
 )
 
 func createSimpleErrorxError() error {
@@ -201,3 +229,50 @@ func consumeResult(err error) {
 func ExportSink() error {
 	return errorSink
 }
+
+func BenchmarkStackTraceErrorxErrorRepeatedPrint100(b *testing.B) {
+	err := function0(100, createErrorxError)
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		emulateErrorPrint(err)
+	}
+	errorSink = err
+	consumeResult(errorSink)
+}
+
+// Emulates an error storm, where plenty of goroutines format distinct errors at once, and reports a tail latency of formatting
+func BenchmarkStackTraceErrorxErrorConcurrentPrint100(b *testing.B) {
+	for _, concurrency := range []int{0, 1 << 20} {
+		name := "Default"
+		if concurrency > 0 {
+			name = "Unlimited"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			errorx.SetSymbolizationConcurrency(concurrency)
+			defer errorx.SetSymbolizationConcurrency(0)
+
+			var mu sync.Mutex
+			latencies := make([]time.Duration, 0, b.N)
+
+			b.SetParallelism(16)
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for pb.Next() {
+					err := function0(100, createErrorxError)
+					start := time.Now()
+					emulateErrorPrint(err)
+					latency := time.Since(start)
+
+					mu.Lock()
+					latencies = append(latencies, latency)
+					mu.Unlock()
+				}
+			})
+			b.StopTimer()
+
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			b.ReportMetric(float64(latencies[len(latencies)*99/100].Nanoseconds()), "p99-ns")
+		})
+	}
+}
@@ -8,12 +8,32 @@ import (
 // ErrorBuilder is a utility to compose an error from type.
 // Typically, a direct usage is not required: either Type methods of helpers like Decorate are sufficient.
 // Only use builder if no simpler alternative is available.
+//
+// Stack trace of an error is defined with this precedence, from highest to lowest:
+// an explicit builder option, that is either WithoutStackTrace or EnhanceStackTrace;
+// a stack trace borrowed from errorx cause, see WithCause;
+// type modifiers, see TypeModifierOmitStackTrace.
+// Explicit builder options are mutually exclusive, and an attempt to combine them results in panic.
 type ErrorBuilder struct {
 	errorType     *Type
 	message       string
 	cause         error
 	mode          callStackBuildMode
 	isTransparent bool
+	propagated    []Trait
+	// stackTrace is collected beforehand, to be used instead of the current one, see withStackTrace
+	stackTrace     *stackTrace
+	isModeForced   bool
+	publicMessage  string
+	details        interface{}
+	externalSystem string
+	reason         string
+	properties     []builderProperty
+}
+
+type builderProperty struct {
+	p     Property
+	value interface{}
 }
 
 // NewErrorBuilder creates error builder from an existing error type.
@@ -36,9 +56,10 @@ func NewErrorBuilder(t *Type) ErrorBuilder {
 // For non-errorx errors, a stack trace is collected.
 // Otherwise, it is inherited by default, as error wrapping is typically performed 'en passe'.
 // Note that even if an original error explicitly omitted the stack trace, it could be added on wrap.
+// As errors are immutable, and a cause necessarily exists before an error which wraps it, a chain of causes never forms a cycle.
 func (eb ErrorBuilder) WithCause(err error) ErrorBuilder {
 	eb.cause = err
-	if Cast(err) != nil {
+	if Cast(err) != nil && !eb.isModeForced {
 		eb.mode = stackTraceBorrow
 	}
 
@@ -58,6 +79,22 @@ func (eb ErrorBuilder) Transparent() ErrorBuilder {
 	return eb
 }
 
+// PropagatingTraits makes an opaque wrap reveal the listed traits of the cause, while its type and other traits remain hidden.
+// This may be used to translate an error into a type of another layer, while retaining, say, a Temporary() trait which affects a retry:
+//
+//	return errorx.NewErrorBuilder(StorageError).WithCause(err).PropagatingTraits(errorx.Temporary()).Create()
+//
+// A trait is checked with the cause just as it would be on its own, so it is only revealed if the cause possesses it.
+// A transparent wrap reveals all traits of the cause regardless.
+func (eb ErrorBuilder) PropagatingTraits(traits ...Trait) ErrorBuilder {
+	if eb.cause == nil {
+		panic("wrong builder usage: wrap modifier without non-nil cause")
+	}
+
+	eb.propagated = append(eb.propagated[:len(eb.propagated):len(eb.propagated)], traits...)
+	return eb
+}
+
 // EnhanceStackTrace is a signal to collect the current stack trace along with the original one, and use both in formatting.
 // If the original error does not hold a stack trace for whatever reason, it will be collected it this point.
 // This is typically a way to handle an error received from another goroutine - say, a worker pool.
@@ -68,12 +105,30 @@ func (eb ErrorBuilder) EnhanceStackTrace() ErrorBuilder {
 		panic("wrong builder usage: wrap modifier without non-nil cause")
 	}
 
+	if eb.isModeForced && eb.mode == stackTraceOmit {
+		panic("wrong builder usage: stack trace enhancement for an error without stack trace")
+	}
+
 	if Cast(eb.cause) != nil {
 		eb.mode = stackTraceEnhance
 	} else {
 		eb.mode = stackTraceCollect
 	}
 
+	eb.isModeForced = true
+	return eb
+}
+
+// WithoutStackTrace prevents an error from having a stack trace, regardless of a type and a cause.
+// Note that with an errorx cause, the stack trace of the cause is not borrowed and is therefore lost in formatting.
+// This option may not be combined with EnhanceStackTrace.
+func (eb ErrorBuilder) WithoutStackTrace() ErrorBuilder {
+	if eb.isModeForced && eb.mode != stackTraceOmit {
+		panic("wrong builder usage: stack trace omission for an error with enhanced stack trace")
+	}
+
+	eb.mode = stackTraceOmit
+	eb.isModeForced = true
 	return eb
 }
 
@@ -90,15 +145,75 @@ func (eb ErrorBuilder) WithConditionallyFormattedMessage(message string, args ..
 	return eb
 }
 
+// WithPublicMessage provides a message which is safe to be exposed to an outside observer, see Error.Public().
+// Unlike the regular message, it is visible through a transparent wrap.
+func (eb ErrorBuilder) WithPublicMessage(message string) ErrorBuilder {
+	eb.publicMessage = message
+	return eb
+}
+
+// WithDetails attaches a single structured payload to an error, such as a description of a failed validation, see Error.Details().
+// Unlike dynamic properties, details are not keyed, are not printed in an error message, and are serialized as a nested object with MarshalJSON.
+func (eb ErrorBuilder) WithDetails(details interface{}) ErrorBuilder {
+	eb.details = details
+	return eb
+}
+
+// WithExternalSystem marks an error as caused by an external system, such as a downstream service, see ExternalSystem().
+// The name is meant to aggregate errors by dependency, say, as a metric label, so it should be of a bounded set of values.
+func (eb ErrorBuilder) WithExternalSystem(name string) ErrorBuilder {
+	eb.externalSystem = name
+	return eb
+}
+
+// WithReason provides a short machine-readable code of an error, such as "invalid_grant" in OAuth, see Reason().
+// It complements a type and a human-readable message, and is meant for protocol-level errors with an established set of codes.
+func (eb ErrorBuilder) WithReason(code string) ErrorBuilder {
+	eb.reason = code
+	return eb
+}
+
+// WithProperty adds a dynamic property to an error, see Error.WithProperty().
+// Unlike a property added to an error once it is created, this one is already in place when hooks are called, see Type.OnCreate().
+func (eb ErrorBuilder) WithProperty(key Property, value interface{}) ErrorBuilder {
+	eb.properties = append(eb.properties[:len(eb.properties):len(eb.properties)], builderProperty{p: key, value: value})
+	return eb
+}
+
+// withStackTrace provides a stack trace collected beforehand, to be used if an error is to have a stack trace of its own.
+func (eb ErrorBuilder) withStackTrace(st *stackTrace) ErrorBuilder {
+	eb.stackTrace = st
+	return eb
+}
+
 // Create returns an error with specified params.
 func (eb ErrorBuilder) Create() *Error {
 	err := &Error{
-		errorType:   eb.errorType,
-		message:     eb.message,
-		cause:       eb.cause,
-		transparent: eb.isTransparent,
-		stackTrace:  eb.assembleStackTrace(),
+		errorType:        eb.errorType,
+		message:          eb.message,
+		cause:            eb.cause,
+		transparent:      eb.isTransparent,
+		propagatedTraits: eb.propagated,
+		stackTrace:       eb.assembleStackTrace(),
+		details:          eb.details,
+	}
+	attachBuildInfo(err)
+
+	if eb.publicMessage != "" {
+		err = err.WithProperty(propertyPublicMessage, eb.publicMessage)
 	}
+	if eb.externalSystem != "" {
+		err = err.WithProperty(propertyExternalSystem, eb.externalSystem)
+	}
+	if eb.reason != "" {
+		err = err.WithProperty(propertyReason, eb.reason)
+	}
+	for _, property := range eb.properties {
+		err = err.WithProperty(property.p, property.value)
+	}
+
+	runCreateHooks(err)
+	checkWrapDepth(err)
 	return err
 }
 
@@ -127,6 +242,12 @@ func (eb ErrorBuilder) assembleStackTrace() *stackTrace {
 }
 
 func (eb ErrorBuilder) collectOriginalStackTrace() *stackTrace {
+	if !isStackTraceSampled() {
+		return nil
+	}
+	if eb.stackTrace != nil {
+		return eb.stackTrace
+	}
 	return collectStackTrace()
 }
 
@@ -135,10 +256,17 @@ func (eb ErrorBuilder) borrowStackTraceFromCause() *stackTrace {
 	if originalStackTrace != nil {
 		return originalStackTrace
 	}
+	if !isStackTraceSampled() {
+		return nil
+	}
 	return collectStackTrace()
 }
 
 func (eb ErrorBuilder) combineStackTraceWithCause() *stackTrace {
+	if !isStackTraceSampled() {
+		return eb.extractStackTraceFromCause(eb.cause)
+	}
+
 	currentStackTrace := collectStackTrace()
 
 	originalStackTrace := eb.extractStackTraceFromCause(eb.cause)
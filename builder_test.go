@@ -20,3 +20,73 @@ func TestBuilderTransparency(t *testing.T) {
 		require.NotEqual(t, testType, err.Type())
 	})
 }
+
+func TestBuilderStackTrace(t *testing.T) {
+	t.Run("WithoutStackTrace", func(t *testing.T) {
+		err := NewErrorBuilder(testType).WithoutStackTrace().Create()
+		require.Nil(t, err.stackTrace)
+	})
+
+	t.Run("WithoutStackTraceWithCause", func(t *testing.T) {
+		err := NewErrorBuilder(testType).WithoutStackTrace().WithCause(testType.New("cause")).Create()
+		require.Nil(t, err.stackTrace)
+
+		err = NewErrorBuilder(testType).WithCause(testType.New("cause")).WithoutStackTrace().Create()
+		require.Nil(t, err.stackTrace)
+	})
+
+	t.Run("EnhanceOverridesTypeModifier", func(t *testing.T) {
+		err := NewErrorBuilder(testTypeSilent).WithCause(testTypeSilent.New("cause")).EnhanceStackTrace().Create()
+		require.NotNil(t, err.stackTrace)
+	})
+
+	t.Run("WithoutStackTraceThenEnhance", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewErrorBuilder(testType).WithoutStackTrace().WithCause(testType.New("cause")).EnhanceStackTrace()
+		})
+	})
+
+	t.Run("EnhanceThenWithoutStackTrace", func(t *testing.T) {
+		require.Panics(t, func() {
+			NewErrorBuilder(testType).WithCause(testType.New("cause")).EnhanceStackTrace().WithoutStackTrace()
+		})
+
+		require.Panics(t, func() {
+			NewErrorBuilder(testType).WithCause(errors.New("raw cause")).EnhanceStackTrace().WithoutStackTrace()
+		})
+	})
+}
+
+func TestBuilderNoCycle(t *testing.T) {
+	root := testType.New("root")
+	descendant := Decorate(testTypeBar1.Wrap(root, "middle"), "top")
+
+	err := NewErrorBuilder(testType).
+		WithConditionallyFormattedMessage("root again").
+		WithCause(descendant).
+		Create()
+	merged := DecorateMany("many", descendant, root, err)
+
+	require.Nil(t, root.Cause())
+	require.Equal(t, "foo.bar: root", root.Error())
+	require.Equal(t, "foo.bar: root again, cause: top, cause: foo.bar1: middle, cause: foo.bar: root", err.Error())
+
+	for _, e := range []error{err, merged} {
+		depth := 0
+		for cause := e; cause != nil; cause = Cast(cause).Cause() {
+			depth++
+			require.True(t, depth < 10, "cyclic chain of causes")
+		}
+	}
+}
+
+func TestBuilderWithProperty(t *testing.T) {
+	err := NewErrorBuilder(testType).
+		WithConditionallyFormattedMessage("test").
+		WithProperty(testInfoProperty2, 1).
+		WithProperty(testInfoProperty3, 2).
+		Create()
+
+	require.Equal(t, "foo.bar: test {prop3: 2, prop2: 1}", err.Error())
+	require.Equal(t, err.Error(), testType.New("test").WithProperty(testInfoProperty2, 1).WithProperty(testInfoProperty3, 2).Error())
+}
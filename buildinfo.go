@@ -0,0 +1,52 @@
+package errorx
+
+import "sync/atomic"
+
+var (
+	propertyBuildVersion = RegisterPrintableProperty("version")
+	propertyBuildCommit  = RegisterPrintableProperty("commit")
+)
+
+// SetBuildInfo makes every error created from now on carry a version and a commit of the build, see Error.BuildInfo().
+// This may be used by error aggregators to segment errors by deployment, without threading the build info through the code.
+// The properties are shared by all errors, so the setting costs no extra allocations on error creation.
+// A wrap of an errorx error does not repeat them, as the build info of the cause is reachable through its chain.
+// Empty version and commit disable the setting, which is the default.
+func SetBuildInfo(version, commit string) {
+	if version == "" && commit == "" {
+		buildInfo.Store((*propertyMap)(nil))
+		return
+	}
+
+	buildInfo.Store((*propertyMap)(nil).with(propertyBuildCommit, commit).with(propertyBuildVersion, version))
+}
+
+// BuildInfo returns a version and a commit of the build this error was created in, see SetBuildInfo().
+// An error wrapped in other errors is searched for the build info, be it an opaque or a transparent wrap.
+func (e *Error) BuildInfo() (version, commit string, ok bool) {
+	for cur := e; cur != nil; cur = Cast(cur.Cause()) {
+		if v, found := cur.OwnProperty(propertyBuildVersion); found {
+			c, _ := cur.OwnProperty(propertyBuildCommit)
+			return v.(string), c.(string), true
+		}
+	}
+
+	return "", "", false
+}
+
+var buildInfo atomic.Value // *propertyMap
+
+// attachBuildInfo sets the build info properties to a newly created error, prior to any other property.
+func attachBuildInfo(err *Error) {
+	properties, _ := buildInfo.Load().(*propertyMap)
+	if properties == nil {
+		return
+	}
+
+	if _, _, ok := Cast(err.cause).BuildInfo(); ok {
+		return
+	}
+
+	err.properties = properties
+	err.printablePropertyCount = 2
+}
@@ -0,0 +1,58 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildInfo(t *testing.T) {
+	before := testType.New("before")
+
+	SetBuildInfo("1.2.3", "abcdef0")
+	defer SetBuildInfo("", "")
+
+	t.Run("Created", func(t *testing.T) {
+		err := testType.New("test")
+		version, commit, ok := err.BuildInfo()
+		require.True(t, ok)
+		require.Equal(t, "1.2.3", version)
+		require.Equal(t, "abcdef0", commit)
+		require.Equal(t, "foo.bar: test {version: 1.2.3, commit: abcdef0}", err.Error())
+	})
+
+	t.Run("CreatedBefore", func(t *testing.T) {
+		_, _, ok := before.BuildInfo()
+		require.False(t, ok)
+		require.Equal(t, "foo.bar: before", before.Error())
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := Decorate(testTypeBar1.Wrap(testType.NewWithNoMessage(), "wrapped"), "decorated")
+		version, commit, ok := err.BuildInfo()
+		require.True(t, ok)
+		require.Equal(t, "1.2.3", version)
+		require.Equal(t, "abcdef0", commit)
+		require.Equal(t, "decorated, cause: foo.bar1: wrapped, cause: foo.bar: {version: 1.2.3, commit: abcdef0}", err.Error())
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		err := Decorate(errors.New("test"), "decorated")
+		_, _, ok := err.BuildInfo()
+		require.True(t, ok)
+	})
+
+	t.Run("Properties", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testInfoProperty2, 1)
+		require.Equal(t, "foo.bar: test {prop2: 1, version: 1.2.3, commit: abcdef0}", err.Error())
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		SetBuildInfo("", "")
+		defer SetBuildInfo("1.2.3", "abcdef0")
+
+		_, _, ok := testType.New("test").BuildInfo()
+		require.False(t, ok)
+	})
+}
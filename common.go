@@ -1,5 +1,9 @@
 package errorx
 
+import (
+	"time"
+)
+
 var (
 	// CommonErrors is a namespace for general purpose errors designed for universal use.
 	// These errors should typically be used in opaque manner, implying no handing in user code.
@@ -7,11 +11,11 @@ var (
 	CommonErrors = NewNamespace("common")
 
 	// IllegalArgument is a type for invalid argument error
-	IllegalArgument = CommonErrors.NewType("illegal_argument")
+	IllegalArgument = CommonErrors.NewType("illegal_argument", Expected())
 	// IllegalState is a type for invalid state error
 	IllegalState = CommonErrors.NewType("illegal_state")
 	// IllegalFormat is a type for invalid format error
-	IllegalFormat = CommonErrors.NewType("illegal_format")
+	IllegalFormat = CommonErrors.NewType("illegal_format", Expected())
 	// InitializationFailed is a type for initialization error
 	InitializationFailed = CommonErrors.NewType("initialization_failed")
 	// DataUnavailable is a type for unavailable data error
@@ -19,21 +23,161 @@ var (
 	// UnsupportedOperation is a type for unsupported operation error
 	UnsupportedOperation = CommonErrors.NewType("unsupported_operation")
 	// RejectedOperation is a type for rejected operation error
-	RejectedOperation = CommonErrors.NewType("rejected_operation")
+	RejectedOperation = CommonErrors.NewType("rejected_operation", Expected())
 	// Interrupted is a type for interruption error
 	Interrupted = CommonErrors.NewType("interrupted")
 	// AssertionFailed is a type for assertion error
 	AssertionFailed = CommonErrors.NewType("assertion_failed")
 	// InternalError is a type for internal error
 	InternalError = CommonErrors.NewType("internal_error")
-	// ExternalError is a type for external error
+	// ExternalError is a type for external error, consider marking the system at fault with ErrorBuilder.WithExternalSystem
 	ExternalError = CommonErrors.NewType("external_error")
 	// ConcurrentUpdate is a type for concurrent update error
 	ConcurrentUpdate = CommonErrors.NewType("concurrent_update")
 	// TimeoutElapsed is a type for timeout error
 	TimeoutElapsed = CommonErrors.NewType("timeout", Timeout())
-	// NotImplemented is an error type for lacking implementation
+	// DuplicateEntry is a type for uniqueness violation error, see NewDuplicate
+	DuplicateEntry = CommonErrors.NewType("duplicate_entry", Duplicate(), Expected())
+	// ConstraintViolation is a type for database constraint violation error, see NewConstraintViolation; it shares the traits of DuplicateEntry
+	ConstraintViolation = CommonErrors.NewType("constraint_violation", Duplicate(), Expected())
+	// PanicError is a type for recovered panic, see HandlePanic
+	PanicError = CommonErrors.NewType("panic")
+	// IOError is a type for partially failed input/output error, see NewIOError
+	IOError = CommonErrors.NewType("io_error")
+	// ContextCancelled is a type for context cancellation error, see WrapContextError
+	ContextCancelled = CommonErrors.NewType("context_cancelled")
+	// ContextDeadlineExceeded is a type for context deadline error, see WrapContextError
+	ContextDeadlineExceeded = CommonErrors.NewType("context_deadline_exceeded", Timeout())
+	// NotImplemented is an error type for lacking implementation, see NewNotImplemented
 	NotImplemented = UnsupportedOperation.NewSubtype("not_implemented")
 	// UnsupportedVersion is a type for unsupported version error
 	UnsupportedVersion = UnsupportedOperation.NewSubtype("version")
+	// ValidationFailed is a type for invalid field error, see NewValidationError
+	ValidationFailed = IllegalArgument.NewSubtype("validation")
+)
+
+// NewDuplicate creates a DuplicateEntry error for a key which violates uniqueness, see ConflictKey.
+func NewDuplicate(key string) *Error {
+	return NewErrorBuilder(DuplicateEntry).
+		WithProperty(propertyConflictKey, key).
+		Create()
+}
+
+// ConflictKey extracts a key which violates uniqueness, see NewDuplicate.
+func ConflictKey(err error) (string, bool) {
+	key, ok := ExtractProperty(err, propertyConflictKey)
+	if !ok {
+		return "", false
+	}
+
+	return key.(string), true
+}
+
+// NewConstraintViolation creates a ConstraintViolation error for a named database constraint, such as a unique or a foreign key, see Constraint.
+// The original cause, typically returned by a database driver, is wrapped.
+// This allows a caller to tell which constraint was violated without an inspection of driver-specific errors.
+func NewConstraintViolation(constraint string, cause error) *Error {
+	return NewErrorBuilder(ConstraintViolation).
+		WithCause(cause).
+		WithProperty(propertyConstraint, constraint).
+		Create()
+}
+
+// Constraint extracts a name of a violated database constraint, see NewConstraintViolation.
+func Constraint(err error) (string, bool) {
+	name, ok := ExtractProperty(err, propertyConstraint)
+	if !ok {
+		return "", false
+	}
+
+	return name.(string), true
+}
+
+// NewNotImplemented creates a NotImplemented error for a stub of a method, see StubbedMethod.
+// NotImplemented error never possesses a Temporary() trait, as a retry is bound to fail the same way.
+func NewNotImplemented(method string) *Error {
+	return NewErrorBuilder(NotImplemented).
+		WithConditionallyFormattedMessage("method %s is not implemented", method).
+		WithProperty(propertyStubbedMethod, method).
+		Create()
+}
+
+// StubbedMethod extracts a name of a method which is not implemented, see NewNotImplemented.
+func StubbedMethod(err error) (string, bool) {
+	method, ok := ExtractProperty(err, propertyStubbedMethod)
+	if !ok {
+		return "", false
+	}
+
+	return method.(string), true
+}
+
+// NewTimeout creates a TimeoutElapsed error for an operation which has not completed in time, see Elapsed.
+func NewTimeout(elapsed time.Duration, operation string) *Error {
+	return NewErrorBuilder(TimeoutElapsed).
+		WithConditionallyFormattedMessage("%s timed out after %s", operation, elapsed).
+		WithProperty(propertyElapsed, elapsed).
+		Create()
+}
+
+// Elapsed extracts a duration an operation has taken before a timeout, see NewTimeout and FromContextWithBudget.
+func Elapsed(err error) (time.Duration, bool) {
+	elapsed, ok := ExtractProperty(err, propertyElapsed)
+	if !ok {
+		return 0, false
+	}
+
+	return elapsed.(time.Duration), true
+}
+
+// ExternalSystem extracts a name of an external system which caused an error, see ErrorBuilder.WithExternalSystem.
+// Along with MetricLabels, it may be used to partition errors in metrics by a downstream dependency.
+func ExternalSystem(err error) (string, bool) {
+	name, ok := ExtractProperty(err, propertyExternalSystem)
+	if !ok {
+		return "", false
+	}
+
+	return name.(string), true
+}
+
+// Reason extracts a machine-readable code of an error, see ErrorBuilder.WithReason.
+func Reason(err error) (string, bool) {
+	code, ok := ExtractProperty(err, propertyReason)
+	if !ok {
+		return "", false
+	}
+
+	return code.(string), true
+}
+
+// NewIOError creates an IOError for an input/output operation which failed after processing some bytes, see BytesProcessed.
+// The original cause, typically returned by an io.Reader or io.Writer, is wrapped.
+func NewIOError(bytesProcessed int64, cause error) *Error {
+	return NewErrorBuilder(IOError).
+		WithCause(cause).
+		WithProperty(propertyBytesProcessed, bytesProcessed).
+		Create()
+}
+
+// BytesProcessed extracts a number of bytes processed before an input/output failure, see NewIOError.
+func BytesProcessed(err error) (int64, bool) {
+	bytes, ok := ExtractProperty(err, propertyBytesProcessed)
+	if !ok {
+		return 0, false
+	}
+
+	return bytes.(int64), true
+}
+
+var (
+	propertyConflictKey    = RegisterPrintableProperty("conflictKey")
+	propertyConstraint     = RegisterPrintableProperty("constraint")
+	propertyField          = RegisterPrintableProperty("field")
+	propertyStubbedMethod  = RegisterPrintableProperty("method")
+	propertyBytesProcessed = RegisterPrintableProperty("bytesProcessed")
+	propertyElapsed        = RegisterPrintableProperty("elapsed")
+	propertyDeadline       = RegisterPrintableProperty("deadline")
+	propertyExternalSystem = RegisterPrintableProperty("externalSystem")
+	propertyReason         = RegisterPrintableProperty("reason")
 )
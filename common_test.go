@@ -0,0 +1,131 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommonConstructors(t *testing.T) {
+	driverErr := errors.New("pq: duplicate key value violates unique constraint")
+
+	tests := []struct {
+		constructor string
+		create      func() *Error
+		errorType   *Type
+		message     string
+		cause       error
+		extract     func(err error) (interface{}, bool)
+		value       interface{}
+		check       func(t *testing.T, err error)
+	}{
+		{
+			constructor: "NewDuplicate",
+			create:      func() *Error { return NewDuplicate("user@example.com") },
+			errorType:   DuplicateEntry,
+			message:     "common.duplicate_entry: {conflictKey: user@example.com}",
+			extract:     func(err error) (interface{}, bool) { return ConflictKey(err) },
+			value:       "user@example.com",
+			check: func(t *testing.T, err error) {
+				require.True(t, IsDuplicate(err))
+			},
+		},
+		{
+			constructor: "NewConstraintViolation",
+			create:      func() *Error { return NewConstraintViolation("users_email_key", driverErr) },
+			errorType:   ConstraintViolation,
+			message:     "common.constraint_violation: {constraint: users_email_key}, cause: pq: duplicate key value violates unique constraint",
+			cause:       driverErr,
+			extract:     func(err error) (interface{}, bool) { return Constraint(err) },
+			value:       "users_email_key",
+			check: func(t *testing.T, err error) {
+				require.True(t, IsDuplicate(err))
+				require.True(t, IsExpected(err))
+				require.Equal(t, ConstraintViolation.TraitNames(), DuplicateEntry.TraitNames())
+			},
+		},
+		{
+			constructor: "NewNotImplemented",
+			create:      func() *Error { return NewNotImplemented("Store.Delete") },
+			errorType:   NotImplemented,
+			message:     "common.unsupported_operation.not_implemented: method Store.Delete is not implemented {method: Store.Delete}",
+			extract:     func(err error) (interface{}, bool) { return StubbedMethod(err) },
+			value:       "Store.Delete",
+			check: func(t *testing.T, err error) {
+				require.True(t, IsOfType(err, UnsupportedOperation))
+				require.False(t, IsTemporary(err))
+			},
+		},
+		{
+			constructor: "NewIOError",
+			create:      func() *Error { return NewIOError(512, io.ErrUnexpectedEOF) },
+			errorType:   IOError,
+			message:     "common.io_error: {bytesProcessed: 512}, cause: unexpected EOF",
+			cause:       io.ErrUnexpectedEOF,
+			extract:     func(err error) (interface{}, bool) { return BytesProcessed(err) },
+			value:       int64(512),
+		},
+		{
+			constructor: "NewTimeout",
+			create:      func() *Error { return NewTimeout(1500*time.Millisecond, "fetch") },
+			errorType:   TimeoutElapsed,
+			message:     "common.timeout: fetch timed out after 1.5s {elapsed: 1.5s}",
+			extract:     func(err error) (interface{}, bool) { return Elapsed(err) },
+			value:       1500 * time.Millisecond,
+			check: func(t *testing.T, err error) {
+				require.True(t, IsTimeout(err))
+			},
+		},
+		{
+			constructor: "WithExternalSystem",
+			create: func() *Error {
+				return NewErrorBuilder(ExternalError).
+					WithConditionallyFormattedMessage("charge failed").
+					WithExternalSystem("payments-api").
+					Create()
+			},
+			errorType: ExternalError,
+			message:   "common.external_error: charge failed {externalSystem: payments-api}",
+			extract:   func(err error) (interface{}, bool) { return ExternalSystem(err) },
+			value:     "payments-api",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.constructor, func(t *testing.T) {
+			err := test.create()
+			require.True(t, IsOfType(err, test.errorType))
+			require.Equal(t, test.message, err.Error())
+			require.Equal(t, test.cause, err.Cause())
+
+			value, ok := test.extract(err)
+			require.True(t, ok)
+			require.Equal(t, test.value, value)
+
+			output := fmt.Sprintf("%+v", err)
+			require.NotContains(t, output, test.constructor+"()", output)
+			require.Contains(t, output, "TestCommonConstructors", output)
+
+			decorated := Decorate(Decorate(err, "decorated"), "decorated again")
+			value, ok = test.extract(decorated)
+			require.True(t, ok)
+			require.Equal(t, test.value, value)
+
+			if test.check != nil {
+				test.check(t, err)
+				test.check(t, decorated)
+			}
+
+			_, ok = test.extract(test.errorType.New("missing"))
+			require.False(t, ok)
+
+			_, ok = test.extract(errors.New("test"))
+			require.False(t, ok)
+		})
+	}
+}
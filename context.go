@@ -0,0 +1,63 @@
+package errorx
+
+import (
+	"context"
+	"time"
+)
+
+// WrapContextError transforms an error of a done context into a typed errorx error.
+// Returns nil if the context is not done yet.
+// Context deadline results in ContextDeadlineExceeded error, which possesses a Timeout() trait,
+// any other reason results in ContextCancelled error.
+// The original context error is kept as a cause, so it remains available via Cause() and errors.Is().
+func WrapContextError(ctx context.Context) *Error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return nil
+	}
+
+	return NewErrorBuilder(contextErrorType(ctxErr)).
+		WithCause(ctxErr).
+		Create()
+}
+
+// FromContextWithBudget is an alternative to WrapContextError for an operation which has failed due to a done context,
+// which also tells how much of a time budget it had: the time elapsed since the operation started, see Elapsed(),
+// and a deadline of the context, if there is one, see Deadline(). This may help to decide whether a retry has a chance to succeed.
+// A type of an error is the same as with WrapContextError: only an exceeded deadline is a timeout,
+// while a cancelled context, even the one with a deadline, is not, as it is a caller who gave up on an operation, and a retry is pointless.
+// Returns nil if the context is not done yet.
+func FromContextWithBudget(ctx context.Context, startedAt time.Time, message string) *Error {
+	ctxErr := ctx.Err()
+	if ctxErr == nil {
+		return nil
+	}
+
+	builder := NewErrorBuilder(contextErrorType(ctxErr)).
+		WithConditionallyFormattedMessage(message).
+		WithCause(ctxErr).
+		WithProperty(propertyElapsed, time.Since(startedAt))
+
+	if deadline, ok := ctx.Deadline(); ok {
+		// a monotonic clock reading is of no use in an output
+		builder = builder.WithProperty(propertyDeadline, deadline.Round(0))
+	}
+	return builder.Create()
+}
+
+func contextErrorType(ctxErr error) *Type {
+	if ctxErr == context.DeadlineExceeded {
+		return ContextDeadlineExceeded
+	}
+	return ContextCancelled
+}
+
+// Deadline extracts a deadline of a context an operation has failed with, see FromContextWithBudget.
+func Deadline(err error) (time.Time, bool) {
+	deadline, ok := ExtractProperty(err, propertyDeadline)
+	if !ok {
+		return time.Time{}, false
+	}
+
+	return deadline.(time.Time), true
+}
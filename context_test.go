@@ -0,0 +1,120 @@
+//go:build go1.13
+// +build go1.13
+
+package errorx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrapContextError(t *testing.T) {
+	t.Run("NotDone", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		require.Nil(t, WrapContextError(ctx))
+	})
+
+	t.Run("Cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := WrapContextError(ctx)
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, ContextCancelled))
+		require.False(t, IsTimeout(err))
+		require.True(t, errors.Is(err, context.Canceled))
+		require.Equal(t, "common.context_cancelled: context canceled", err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "WrapContextError()", output)
+		require.Contains(t, output, "TestWrapContextError", output)
+	})
+
+	t.Run("DeadlineExceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+		defer cancel()
+		<-ctx.Done()
+
+		err := WrapContextError(ctx)
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, ContextDeadlineExceeded))
+		require.True(t, IsTimeout(err))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+		require.False(t, errors.Is(err, context.Canceled))
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := Decorate(WrapContextError(ctx), "request aborted")
+		require.True(t, IsOfType(err, ContextCancelled))
+		require.True(t, errors.Is(err, context.Canceled))
+	})
+}
+
+func TestFromContextWithBudget(t *testing.T) {
+	t.Run("NotDone", func(t *testing.T) {
+		require.Nil(t, FromContextWithBudget(context.Background(), time.Now(), "fetch"))
+	})
+
+	t.Run("Deadline", func(t *testing.T) {
+		startedAt := time.Now()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+
+		err := FromContextWithBudget(ctx, startedAt, "fetch")
+		require.True(t, IsOfType(err, ContextDeadlineExceeded))
+		require.True(t, IsTimeout(err))
+		require.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		elapsed, ok := Elapsed(err)
+		require.True(t, ok)
+		require.True(t, elapsed >= time.Millisecond, elapsed)
+
+		ctxDeadline, _ := ctx.Deadline()
+		deadline, ok := Deadline(Decorate(err, "decorated"))
+		require.True(t, ok)
+		require.True(t, ctxDeadline.Equal(deadline), deadline)
+		require.Contains(t, err.Error(), "common.context_deadline_exceeded: fetch {deadline: ", err.Error())
+		require.NotContains(t, err.Error(), "m=", err.Error())
+	})
+
+	t.Run("CancelledBeforeDeadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+		cancel()
+
+		err := FromContextWithBudget(ctx, time.Now(), "fetch")
+		require.True(t, IsOfType(err, ContextCancelled))
+		require.False(t, IsTimeout(err))
+		require.True(t, errors.Is(err, context.Canceled))
+
+		_, ok := Deadline(err)
+		require.True(t, ok)
+	})
+
+	t.Run("NoDeadline", func(t *testing.T) {
+		startedAt := time.Now().Add(-time.Second)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := FromContextWithBudget(ctx, startedAt, "fetch")
+		require.True(t, IsOfType(err, ContextCancelled))
+		require.False(t, IsTimeout(err))
+
+		elapsed, ok := Elapsed(err)
+		require.True(t, ok)
+		require.True(t, elapsed >= time.Second, elapsed)
+
+		_, ok = Deadline(err)
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,97 @@
+package errorx
+
+import (
+	"reflect"
+)
+
+// EqualIgnoring checks if two errors are equal, disregarding the values of provided properties.
+// Errors are equal if they are of the same type, have the same message and the same dynamic properties,
+// and the same holds true for their causes and underlying errors. Traits belong to a type, so they are compared implicitly.
+// Non-errorx errors are considered equal if they are of the same type and have the same message.
+// Stack traces are not compared, nor are the properties errorx keeps for itself, such as a point of recovery from panic.
+//
+// This is designed for tests, where some properties, like timestamps and IDs, may differ from run to run.
+func EqualIgnoring(a, b error, ignore ...Property) bool {
+	ignored := make(map[Property]struct{}, len(ignore))
+	for _, p := range ignore {
+		ignored[p] = struct{}{}
+	}
+
+	return equalIgnoring(a, b, ignored)
+}
+
+func equalIgnoring(a, b error, ignored map[Property]struct{}) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	typedA, typedB := Cast(a), Cast(b)
+	if typedA == nil || typedB == nil {
+		return typedA == nil && typedB == nil &&
+			reflect.TypeOf(a) == reflect.TypeOf(b) && a.Error() == b.Error()
+	}
+
+	if typedA.errorType != typedB.errorType ||
+		typedA.transparent != typedB.transparent ||
+		typedA.message != typedB.message {
+		return false
+	}
+
+	if !equalErrorSlices(typedA.underlying(), typedB.underlying(), ignored) {
+		return false
+	}
+
+	if !equalPropertyValues(typedA.ownProperties(ignored), typedB.ownProperties(ignored)) {
+		return false
+	}
+
+	return equalIgnoring(typedA.cause, typedB.cause, ignored)
+}
+
+func equalErrorSlices(a, b []error, ignored map[Property]struct{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !equalIgnoring(a[i], b[i], ignored) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func equalPropertyValues(a, b map[Property]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for key, valueA := range a {
+		valueB, ok := b[key]
+		if !ok || !reflect.DeepEqual(valueA, valueB) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ownProperties collects the current values of all properties of this particular error, except for the ignored ones.
+func (e *Error) ownProperties(ignored map[Property]struct{}) map[Property]interface{} {
+	result := make(map[Property]interface{})
+	for m := e.properties; m != nil; m = m.next {
+		if m.p.internal {
+			continue
+		}
+		if _, ok := ignored[m.p]; ok {
+			continue
+		}
+		if _, ok := result[m.p]; ok {
+			continue
+		}
+		result[m.p] = m.value
+	}
+
+	return result
+}
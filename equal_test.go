@@ -0,0 +1,80 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPropertyTimestamp = RegisterPrintableProperty("timestamp")
+	testPropertyRequestID = RegisterProperty("requestID")
+)
+
+func TestEqualIgnoring(t *testing.T) {
+	create := func(timestamp time.Time, requestID string, cause error) error {
+		err := testType.Wrap(cause, "request failed").
+			WithProperty(testPropertyTimestamp, timestamp).
+			WithProperty(testPropertyRequestID, requestID)
+		return Decorate(err, "handler")
+	}
+
+	t.Run("Same", func(t *testing.T) {
+		now := time.Now()
+		require.True(t, EqualIgnoring(create(now, "1", errors.New("bad")), create(now, "1", errors.New("bad"))))
+	})
+
+	t.Run("IgnoredProperty", func(t *testing.T) {
+		a := create(time.Now(), "1", errors.New("bad"))
+		b := create(time.Now().Add(time.Hour), "1", errors.New("bad"))
+		require.False(t, EqualIgnoring(a, b))
+		require.True(t, EqualIgnoring(a, b, testPropertyTimestamp))
+	})
+
+	t.Run("NonIgnoredProperty", func(t *testing.T) {
+		a := create(time.Now(), "1", errors.New("bad"))
+		b := create(time.Now(), "2", errors.New("bad"))
+		require.False(t, EqualIgnoring(a, b, testPropertyTimestamp))
+		require.True(t, EqualIgnoring(a, b, testPropertyTimestamp, testPropertyRequestID))
+	})
+
+	t.Run("MissingProperty", func(t *testing.T) {
+		a := testType.New("test").WithProperty(testPropertyRequestID, "1")
+		b := testType.New("test")
+		require.False(t, EqualIgnoring(a, b))
+		require.True(t, EqualIgnoring(a, b, testPropertyRequestID))
+	})
+
+	t.Run("DifferentCause", func(t *testing.T) {
+		now := time.Now()
+		require.False(t, EqualIgnoring(create(now, "1", errors.New("bad")), create(now, "1", errors.New("worse"))))
+		require.False(t, EqualIgnoring(create(now, "1", errors.New("bad")), create(now, "1", testType.New("bad"))))
+	})
+
+	t.Run("IgnoredPropertyInCause", func(t *testing.T) {
+		a := testTypeBar1.Wrap(testType.New("test").WithProperty(testPropertyRequestID, "1"), "outer")
+		b := testTypeBar1.Wrap(testType.New("test").WithProperty(testPropertyRequestID, "2"), "outer")
+		require.False(t, EqualIgnoring(a, b))
+		require.True(t, EqualIgnoring(a, b, testPropertyRequestID))
+	})
+
+	t.Run("DifferentType", func(t *testing.T) {
+		require.False(t, EqualIgnoring(testType.New("test"), testSubtype0.New("test")))
+		require.False(t, EqualIgnoring(testType.New("test"), testType.New("other")))
+	})
+
+	t.Run("Underlying", func(t *testing.T) {
+		a := testType.New("test").WithUnderlyingErrors(testTypeBar1.New("hidden").WithProperty(testPropertyRequestID, "1"))
+		b := testType.New("test").WithUnderlyingErrors(testTypeBar1.New("hidden").WithProperty(testPropertyRequestID, "2"))
+		require.False(t, EqualIgnoring(a, b))
+		require.True(t, EqualIgnoring(a, b, testPropertyRequestID))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		require.True(t, EqualIgnoring(nil, nil))
+		require.False(t, EqualIgnoring(testType.New("test"), nil))
+		require.False(t, EqualIgnoring(nil, errors.New("test")))
+	})
+}
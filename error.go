@@ -9,6 +9,8 @@ import (
 // Error is an instance of error object.
 // At the moment of creation, Error collects information based on context, creation modifiers and type it belongs to.
 // Error is mostly immutable, and distinct errors composition is achieved through wrap.
+// Methods which inspect an error, such as Error(), Property() or HasTrait(), are safe to call on a nil *Error,
+// which has an empty output and no cause, properties or traits.
 type Error struct {
 	message    string
 	errorType  *Type
@@ -17,13 +19,20 @@ type Error struct {
 	// properties are used both for public properties inherited through "transparent" wrapping
 	// and for some optional per-instance information like "underlying errors"
 	properties *propertyMap
-
-	transparent            bool
-	hasUnderlying          bool
+	// details is an optional structured payload, see ErrorBuilder.WithDetails
+	details interface{}
+	// propagatedTraits are the traits of a cause which an opaque wrap reveals, see ErrorBuilder.PropagatingTraits
+	propagatedTraits []Trait
+
+	transparent   bool
+	hasUnderlying bool
+	// sanitized marks a result of Error.Public(), which is not passed on with properties
+	sanitized              bool
 	printablePropertyCount uint8
 }
 
 var _ fmt.Formatter = (*Error)(nil)
+var _ io.WriterTo = (*Error)(nil)
 
 // WithProperty adds a dynamic property to error instance.
 // If an error already contained another value for the same property, it is overwritten.
@@ -64,10 +73,38 @@ func (e *Error) WithUnderlyingErrors(errs ...error) *Error {
 	return errorCopy
 }
 
+// Clone returns a deep copy of an error, which shares no mutable state with the original.
+// Dynamic properties, underlying errors and a stack trace are all copied.
+// The cause is shared rather than copied, as well as property values and details, which are treated as immutable.
+func (e *Error) Clone() *Error {
+	errorCopy := *e
+	errorCopy.stackTrace = e.stackTrace.clone()
+
+	var properties []*propertyMap
+	for m := e.properties; m != nil; m = m.next {
+		properties = append(properties, m)
+	}
+
+	errorCopy.properties = nil
+	for i := len(properties) - 1; i >= 0; i-- {
+		value := properties[i].value
+		if underlying, ok := value.([]error); ok && properties[i].p == propertyUnderlying {
+			underlyingCopy := make([]error, len(underlying))
+			copy(underlyingCopy, underlying)
+			value = underlyingCopy
+		}
+
+		errorCopy.properties = errorCopy.properties.with(properties[i].p, value)
+	}
+
+	return &errorCopy
+}
+
 // Property extracts a dynamic property value from an error.
 // A property may belong to this error or be extracted from the original cause.
 // The transparency rules are respected to some extent: both the original cause and the transparent wrapper
 // may have accessible properties, but an opaque wrapper hides the original properties.
+// A non-errorx cause revealed by a transparent wrap may have properties recognised by an adapter, see RegisterErrorAdapter.
 func (e *Error) Property(key Property) (interface{}, bool) {
 	cause := e
 	for cause != nil {
@@ -80,29 +117,101 @@ func (e *Error) Property(key Property) (interface{}, bool) {
 			break
 		}
 
-		cause = Cast(cause.Cause())
+		next := cause.Cause()
+		if next != nil && Cast(next) == nil {
+			return adaptedProperty(next, key)
+		}
+
+		cause = Cast(next)
 	}
 
 	return nil, false
 }
 
+// VisitProperties calls a visitor for each dynamic property visible from this error, along with its value.
+// Visibility rules are the same as with Property(), and each property is visited once, with the value Property() would return.
+// This is designed for a system layer which transforms errors into another format, say, a tracing system span.
+// Properties errorx keeps for itself, such as underlying errors, are not visited.
+func (e *Error) VisitProperties(visitor func(key Property, value interface{})) {
+	for _, m := range e.visibleProperties(func(p Property) bool { return !p.internal }) {
+		visitor(m.p, m.value)
+	}
+}
+
+// OwnProperty extracts a dynamic property value which belongs to this particular error.
+// Unlike Property(), it never looks into the cause, even if this error is a transparent wrapper.
+// This may be used to tell a property of a wrapper apart from the one inherited from the cause.
+func (e *Error) OwnProperty(key Property) (interface{}, bool) {
+	if e == nil {
+		return nil, false
+	}
+	return e.properties.get(key)
+}
+
+// MergePropertiesFrom returns a copy of this error with all the dynamic properties visible from src, both printable and non-printable.
+// A property already visible from this error, as with Property(), retains its value and is not overwritten.
+// This may be used to translate an error into another type while retaining its context.
+// Properties errorx keeps for itself are not merged: neither underlying errors, nor a public message,
+// so that a merge does not make an error public along with src, see AssertPublic.
+// If src is not an errorx error, the error is returned as is.
+func (e *Error) MergePropertiesFrom(src error) *Error {
+	typedSrc := Cast(src)
+	if typedSrc == nil {
+		return e
+	}
+
+	properties := typedSrc.visibleProperties(func(p Property) bool { return !p.internal })
+
+	result := e
+	for i := len(properties) - 1; i >= 0; i-- {
+		if _, ok := e.Property(properties[i].p); ok {
+			continue
+		}
+
+		result = result.WithProperty(properties[i].p, properties[i].value)
+	}
+
+	return result
+}
+
 // HasTrait checks if an error possesses the expected trait.
 // Trait check works just as a type check would: opaque wrap hides the traits of the cause.
+// The only exception is a trait an opaque wrap explicitly propagates from its cause, see ErrorBuilder.PropagatingTraits().
+// If a transparent wrap reveals a non-errorx cause, some of its traits may be recognised, see Adopt().
 // Traits are always properties of a type rather than of an instance, so trait check is an alternative to a type check.
 // This alternative is preferable, though, as it is less brittle and generally creates less of a dependency.
 func (e *Error) HasTrait(key Trait) bool {
 	cause := e
 	for cause != nil {
 		if !cause.transparent {
-			return cause.errorType.HasTrait(key)
+			if cause.errorType.HasTrait(key) {
+				return true
+			}
+			if !cause.propagatesTrait(key) {
+				return false
+			}
 		}
 
-		cause = Cast(cause.Cause())
+		next := cause.Cause()
+		if next != nil && Cast(next) == nil {
+			return hasForeignTrait(next, key)
+		}
+
+		cause = Cast(next)
 	}
 
 	return false
 }
 
+func (e *Error) propagatesTrait(key Trait) bool {
+	for _, trait := range e.propagatedTraits {
+		if trait == key {
+			return true
+		}
+	}
+	return false
+}
+
 // IsOfType is a proper type check for an error.
 // It takes the transparency and error types hierarchy into account,
 // so that type check against any supertype of the original cause passes.
@@ -119,6 +228,47 @@ func (e *Error) IsOfType(t *Type) bool {
 	return false
 }
 
+// Details returns a structured payload attached to an error, see ErrorBuilder.WithDetails().
+// Visibility rules are the same as with Property(): details of the original cause are visible through a transparent wrap.
+// Returns nil if there are no details.
+func (e *Error) Details() interface{} {
+	cause := e
+	for cause != nil {
+		if cause.details != nil {
+			return cause.details
+		}
+
+		if !cause.transparent {
+			break
+		}
+
+		cause = Cast(cause.Cause())
+	}
+
+	return nil
+}
+
+// IsOfTypeName is a type check against a full name of a type, such as one received in a serialized error.
+// It follows the same rules as IsOfType(), so that a check against a full name of any supertype passes.
+// This does not require a type to be importable, but is more brittle: a misspelled name simply fails the check.
+func (e *Error) IsOfTypeName(fullName string) bool {
+	cause := e
+	for cause != nil {
+		if !cause.transparent {
+			for t := cause.errorType; t != nil; t = t.parent {
+				if t.FullName() == fullName {
+					return true
+				}
+			}
+			return false
+		}
+
+		cause = Cast(cause.Cause())
+	}
+
+	return false
+}
+
 // Type returns the exact type of this error.
 // With transparent wrapping, such as in Decorate(), returns the type of the original cause.
 // The result is always not nil, even if the resulting type is impossible to successfully type check against.
@@ -139,11 +289,39 @@ func (e *Error) Type() *Type {
 	return foreignType
 }
 
+// HasStackTrace checks if an error has a stack trace, and therefore if it is included in a full (%+v) output.
+// An error may lack one due to ErrorBuilder.WithoutStackTrace(), TypeModifierOmitStackTrace of its type or namespace,
+// or a stack trace sampling, see SetStackTraceSampling(). A wrapper borrows the stack trace of its cause, if there is one.
+func (e *Error) HasStackTrace() bool {
+	return e != nil && e.stackTrace != nil
+}
+
+// Origin returns a location in code where an error has originated, that is, the first frame of its stack trace
+// which belongs neither to runtime nor to errorx itself. File path is transformed as it is in a stack trace output.
+// This is much cheaper than a full stack trace output, and may be used, say, as a short prefix of a log message.
+// If an error has no stack trace, the result is not ok.
+func (e *Error) Origin() (file string, line int, function string, ok bool) {
+	if e == nil || e.stackTrace == nil {
+		return "", 0, "", false
+	}
+
+	frame, ok := e.stackTrace.origin()
+	if !ok {
+		return "", 0, "", false
+	}
+
+	transformLine := stackTraceTransformer.transform.Load().(StackTraceFilePathTransformer)
+	return transformLine(frame.File), frame.Line, frame.Function, true
+}
+
 // Message returns a message of this particular error, disregarding the cause.
 // The result of this method, like a result of an Error() method, should never be used to infer the meaning of an error.
 // In most cases, message is only used as a part of formatting to print error contents into a log file.
 // Manual extraction may be required, however, to transform an error into another format - say, API response.
 func (e *Error) Message() string {
+	if e == nil {
+		return ""
+	}
 	return e.message
 }
 
@@ -153,46 +331,131 @@ func (e *Error) Message() string {
 // Manually extracting cause defeats features such as opaque wrap, behaviour of properties etc.
 // This method is, therefore, reserved for system utilities, not for general use.
 func (e *Error) Cause() error {
+	if e == nil {
+		return nil
+	}
+	return e.cause
+}
+
+// Unwrap returns the immediate (wrapped) cause of current error, see Cause().
+// It is provided for interoperability with standard library errors.Is() and errors.As(),
+// so that a sentinel error or a specific error type stays discoverable even when it is wrapped.
+// Note that, unlike errorx type checks, this ignores opaqueness of a wrap.
+func (e *Error) Unwrap() error {
+	if e == nil {
+		return nil
+	}
 	return e.cause
 }
 
 // Format implements the Formatter interface.
 // Supported verbs:
 //
-// 		%s		simple message output
-// 		%v		same as %s
-// 		%+v		full output complete with a stack trace
+//	%s		simple message output
+//	%v		same as %s
+//	%+v		full output complete with a stack trace
 //
 // In is nearly always preferable to use %+v format.
 // If a stack trace is not required, it should be omitted at the moment of creation rather in formatting.
 func (e *Error) Format(s fmt.State, verb rune) {
-	message := e.fullMessage()
+	if e == nil {
+		return
+	}
+
 	switch verb {
 	case 'v':
-		io.WriteString(s, message)
 		if s.Flag('+') {
-			e.stackTrace.Format(s, verb)
+			e.WriteTo(s)
+			return
 		}
+		io.WriteString(s, e.fullMessage())
 	case 's':
-		io.WriteString(s, message)
+		io.WriteString(s, e.fullMessage())
+	}
+}
+
+// WriteTo implements the io.WriterTo interface.
+// Output is exactly the same as with %+v format, complete with a stack trace, see also SetMaxPrintedCauseDepth() and SetCausePrintMode().
+// Stack trace is written frame by frame rather than collected into a single string beforehand,
+// which makes it a preferable way to dump a lot of errors with deep stack traces, say, into a log file.
+func (e *Error) WriteTo(w io.Writer) (int64, error) {
+	if e == nil {
+		return 0, nil
+	}
+
+	mode := causePrintMode()
+
+	causeDepth := printedCauseDepth()
+	if mode == CausePrintModeOuterOnly {
+		causeDepth = 0
+	}
+
+	cw := &countingWriter{w: w}
+	io.WriteString(cw, e.formattedMessage(messageFormat{
+		causeDepth:     causeDepth,
+		causeSeparator: defaultCauseSeparator,
+		allProperties:  showAllProperties(),
+	}))
+	if url, ok := e.Type().HelpURL(); ok {
+		io.WriteString(cw, "\n see: ")
+		io.WriteString(cw, url)
+	}
+
+	if mode == CausePrintModeFull {
+		e.stackTrace.writeTo(cw)
+	} else {
+		e.stackTrace.writeOuterTo(cw)
 	}
+	return cw.n, cw.err
 }
 
 // Error implements the error interface.
 // A result is the same as with %s formatter and does not contain a stack trace.
 func (e *Error) Error() string {
+	if e == nil {
+		return ""
+	}
 	return e.fullMessage()
 }
 
 func (e *Error) fullMessage() string {
+	return e.formattedMessage(messageFormat{
+		causeDepth:     -1,
+		causeSeparator: causeSeparator(),
+	})
+}
+
+// messageFormat defines an output of an error message, which is different for one-line and full outputs
+type messageFormat struct {
+	// causeDepth is a number of cause levels to be included in a message, negative depth means no limit
+	causeDepth     int
+	causeSeparator string
+	// allProperties includes non-printable properties in a message
+	allProperties bool
+	// audience, if not empty, filters properties by their visibility, see FormatFor
+	audience string
+}
+
+func (f messageFormat) includesProperty(p Property) bool {
+	switch {
+	case p.internal:
+		return false
+	case f.audience != "" && len(p.audiences) > 0:
+		return p.isVisibleTo(f.audience)
+	default:
+		return p.printable || f.allProperties
+	}
+}
+
+func (e *Error) formattedMessage(f messageFormat) string {
 	if e.transparent {
-		return e.messageWithUnderlyingInfo()
+		return e.messageWithUnderlyingInfo(f)
 	}
-	return joinStringsIfNonEmpty(": ", e.errorType.FullName(), e.messageWithUnderlyingInfo())
+	return joinStringsIfNonEmpty(": ", e.errorType.FullName(), e.messageWithUnderlyingInfo(f))
 }
 
-func (e *Error) messageWithUnderlyingInfo() string {
-	return joinStringsIfNonEmpty(" ", e.messageText(), e.underlyingInfo())
+func (e *Error) messageWithUnderlyingInfo(f messageFormat) string {
+	return joinStringsIfNonEmpty(" ", e.messageText(f), e.underlyingInfo())
 }
 
 func (e *Error) underlyingInfo() string {
@@ -209,21 +472,24 @@ func (e *Error) underlyingInfo() string {
 	return fmt.Sprintf("(hidden: %s)", joinStringsIfNonEmpty(", ", infos...))
 }
 
-func (e *Error) messageFromProperties() string {
-	if e.printablePropertyCount == 0 {
+func (e *Error) messageFromProperties(f messageFormat) string {
+	if e.printablePropertyCount == 0 && ((!f.allProperties && f.audience == "") || e.properties == nil) {
 		return ""
 	}
 	uniq := make(map[Property]struct{}, e.printablePropertyCount)
 	strs := make([]string, 0, e.printablePropertyCount)
 	for m := e.properties; m != nil; m = m.next {
-		if !m.p.printable {
+		if !f.includesProperty(m.p) {
 			continue
 		}
 		if _, ok := uniq[m.p]; ok {
 			continue
 		}
 		uniq[m.p] = struct{}{}
-		strs = append(strs, fmt.Sprintf("%s: %v", m.p.label, m.value))
+		strs = append(strs, m.p.label+": "+m.p.FormatValue(m.value))
+	}
+	if len(strs) == 0 {
+		return ""
 	}
 	return "{" + strings.Join(strs, ", ") + "}"
 }
@@ -238,12 +504,66 @@ func (e *Error) underlying() []error {
 	return u.([]error)
 }
 
-func (e *Error) messageText() string {
-	message := joinStringsIfNonEmpty(" ", e.message, e.messageFromProperties())
-	if cause := e.Cause(); cause != nil {
-		return joinStringsIfNonEmpty(", cause: ", message, cause.Error())
+func (e *Error) messageText(f messageFormat) string {
+	message := joinStringsIfNonEmpty(" ", truncateMessage(e.message), e.messageFromProperties(f))
+	cause := e.Cause()
+	switch {
+	case cause == nil:
+		return message
+	case f.causeDepth == 0:
+		return joinStringsIfNonEmpty(" ", message, fmt.Sprintf("...(%d more causes)", countCauses(cause)))
+	}
+
+	if typedCause := Cast(cause); typedCause != nil {
+		causeFormat := f
+		causeFormat.causeDepth--
+		return joinStringsIfNonEmpty(f.causeSeparator, message, typedCause.formattedMessage(causeFormat))
+	}
+	return joinStringsIfNonEmpty(f.causeSeparator, message, truncateMessage(cause.Error()))
+}
+
+func countCauses(cause error) int {
+	count := 0
+	for cause != nil {
+		count++
+		typedCause := Cast(cause)
+		if typedCause == nil {
+			break
+		}
+
+		cause = typedCause.Cause()
+	}
+
+	return count
+}
+
+// countingWriter keeps track of bytes written, and stops writing after the first failure.
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
+	}
+
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
+}
+
+func (cw *countingWriter) WriteString(s string) (int, error) {
+	if cw.err != nil {
+		return 0, cw.err
 	}
-	return message
+
+	n, err := io.WriteString(cw.w, s)
+	cw.n += int64(n)
+	cw.err = err
+	return n, err
 }
 
 func joinStringsIfNonEmpty(delimiter string, parts ...string) string {
@@ -0,0 +1,30 @@
+//go:build !go1.13
+// +build !go1.13
+
+package errorx
+
+import (
+	"os"
+)
+
+func isNotExistError(err error) bool {
+	return os.IsNotExist(err)
+}
+
+// isError is a limited substitute for errors.Is, which only looks through the causes of errorx errors
+func isError(err, target error) bool {
+	for err != nil {
+		if err == target {
+			return true
+		}
+
+		typedErr := Cast(err)
+		if typedErr == nil {
+			return false
+		}
+
+		err = typedErr.Cause()
+	}
+
+	return err == target
+}
@@ -0,0 +1,17 @@
+//go:build go1.13
+// +build go1.13
+
+package errorx
+
+import (
+	"errors"
+	"os"
+)
+
+func isNotExistError(err error) bool {
+	return errors.Is(err, os.ErrNotExist)
+}
+
+func isError(err, target error) bool {
+	return errors.Is(err, target)
+}
@@ -0,0 +1,81 @@
+//go:build go1.13
+// +build go1.13
+
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnwrapNotExist(t *testing.T) {
+	_, openErr := os.Open("no_such_file.go")
+	require.Error(t, openErr)
+
+	t.Run("Decorate", func(t *testing.T) {
+		err := Decorate(openErr, "failed to read config")
+		require.True(t, errors.Is(err, os.ErrNotExist))
+
+		var pathErr *os.PathError
+		require.True(t, errors.As(err, &pathErr))
+		require.Equal(t, openErr, pathErr)
+	})
+
+	t.Run("Wrap", func(t *testing.T) {
+		err := IllegalState.Wrap(Decorate(openErr, "failed to read config"), "bad state")
+		require.True(t, errors.Is(err, os.ErrNotExist))
+		require.False(t, IsNotFound(err))
+	})
+
+	t.Run("Adopt", func(t *testing.T) {
+		err := Adopt(openErr)
+		require.True(t, errors.Is(err, os.ErrNotExist))
+		require.True(t, IsNotFound(err))
+		require.True(t, IsNotFound(Decorate(err, "failed to read config")))
+		require.Equal(t, openErr.Error(), err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "Adopt()", output)
+		require.Contains(t, output, "TestUnwrapNotExist", output)
+	})
+
+	t.Run("AdoptWrapped", func(t *testing.T) {
+		err := Adopt(fmt.Errorf("config: %w", openErr))
+		require.True(t, IsNotFound(err))
+	})
+}
+
+func TestIsTrait(t *testing.T) {
+	t.Run("Errorx", func(t *testing.T) {
+		require.True(t, IsTrait(TimeoutElapsed.New("test"), Timeout()))
+		require.True(t, IsTrait(Decorate(TimeoutElapsed.New("test"), "decorated"), Timeout()))
+		require.False(t, IsTrait(InternalError.Wrap(TimeoutElapsed.New("test"), "wrapped"), Timeout()))
+		require.False(t, IsTrait(TimeoutElapsed.New("test"), NotFound()))
+	})
+
+	t.Run("UnderStandardError", func(t *testing.T) {
+		err := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", Decorate(TimeoutElapsed.New("test"), "decorated")))
+		require.False(t, HasTrait(err, Timeout()))
+		require.True(t, IsTrait(err, Timeout()))
+		require.False(t, IsTrait(err, Temporary()))
+	})
+
+	t.Run("StandardErrorInBetween", func(t *testing.T) {
+		err := Decorate(fmt.Errorf("wrapped: %w", TimeoutElapsed.New("test")), "decorated")
+		require.False(t, HasTrait(err, Timeout()))
+		require.True(t, IsTrait(err, Timeout()))
+
+		err = InternalError.Wrap(fmt.Errorf("wrapped: %w", TimeoutElapsed.New("test")), "opaque")
+		require.False(t, IsTrait(err, Timeout()))
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		require.True(t, IsTrait(fmt.Errorf("wrapped: %w", os.ErrNotExist), NotFound()))
+		require.False(t, IsTrait(errors.New("test"), Timeout()))
+		require.False(t, IsTrait(nil, Timeout()))
+	})
+}
@@ -1,8 +1,10 @@
 package errorx
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -107,6 +109,54 @@ func TestImmutableError(t *testing.T) {
 	})
 }
 
+func TestErrorClone(t *testing.T) {
+	cause := testTypeBar1.New("cause")
+	err := testType.Wrap(cause, "test").
+		WithProperty(testProperty0, 0).
+		WithProperty(testInfoProperty2, 2).
+		WithUnderlyingErrors(testSubtype0.NewWithNoMessage())
+
+	clone := err.Clone()
+	require.Equal(t, err.Error(), clone.Error())
+	require.Equal(t, fmt.Sprintf("%+v", err), fmt.Sprintf("%+v", clone))
+	require.True(t, clone.Cause() == cause, "cause is shared")
+
+	t.Run("Properties", func(t *testing.T) {
+		for m := clone.properties; m != nil; m = m.next {
+			if m.p == testProperty0 {
+				m.value = 100500
+			}
+		}
+
+		property0, ok := err.Property(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 0, property0)
+
+		property0, ok = clone.Property(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 100500, property0)
+		require.Equal(t, "foo.bar: test {prop2: 2}, cause: foo.bar1: cause (hidden: foo.bar.internal)", clone.Error())
+	})
+
+	t.Run("Underlying", func(t *testing.T) {
+		clone.underlying()[0] = testSubtype1.NewWithNoMessage()
+		require.True(t, IsOfType(err.underlying()[0], testSubtype0))
+		require.False(t, IsOfType(err.underlying()[0], testSubtype1))
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		require.Equal(t, err.stackTrace.pc, clone.stackTrace.pc)
+		clone.stackTrace.pc[0] = 0
+		require.NotEqual(t, err.stackTrace.pc, clone.stackTrace.pc)
+	})
+
+	t.Run("WithoutStackTrace", func(t *testing.T) {
+		silent := testTypeSilent.New("silent").Clone()
+		require.Nil(t, silent.stackTrace)
+		require.Equal(t, "foo.bar.silent: silent", silent.Error())
+	})
+}
+
 func TestErrorStackTrace(t *testing.T) {
 	err := createErrorFuncInStackTrace(testType)
 	output := fmt.Sprintf("%+v", err)
@@ -158,3 +208,101 @@ func createWrappedErrorFuncInnerInStackTrace(et *Type) *Error {
 func createErrorInAnotherGoroutine(et *Type, channel chan *Error) {
 	channel <- et.NewWithNoMessage()
 }
+
+func TestErrorWriteTo(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		err := createErrorFuncInStackTrace(testType)
+		buf := &bytes.Buffer{}
+		n, writeErr := err.WriteTo(buf)
+		require.NoError(t, writeErr)
+		require.EqualValues(t, buf.Len(), n)
+		require.Equal(t, fmt.Sprintf("%+v", err), buf.String())
+	})
+
+	t.Run("Enhanced", func(t *testing.T) {
+		err := Decorate(createWrappedErrorFuncOuterInStackTrace(testType), "outer")
+		buf := &bytes.Buffer{}
+		_, writeErr := err.WriteTo(buf)
+		require.NoError(t, writeErr)
+		require.Equal(t, fmt.Sprintf("%+v", err), buf.String())
+		require.Contains(t, buf.String(), "createErrorInAnotherGoroutine", buf.String())
+	})
+
+	t.Run("NoTrace", func(t *testing.T) {
+		err := testTypeSilent.New("silent")
+		buf := &bytes.Buffer{}
+		_, writeErr := err.WriteTo(buf)
+		require.NoError(t, writeErr)
+		require.Equal(t, "foo.bar.silent: silent", buf.String())
+	})
+
+	t.Run("WriterFailure", func(t *testing.T) {
+		err := createErrorFuncInStackTrace(testType)
+		n, writeErr := err.WriteTo(failingWriter{})
+		require.Error(t, writeErr)
+		require.EqualValues(t, 0, n)
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, io.ErrShortWrite
+}
+
+func TestNilError(t *testing.T) {
+	var err *Error
+
+	require.Equal(t, "", err.Error())
+	require.Equal(t, "", err.Message())
+	require.Equal(t, "", fmt.Sprintf("%v", err))
+	require.Equal(t, "", fmt.Sprintf("%+v", err))
+	require.Equal(t, "", fmt.Sprintf("%s", err))
+	require.Nil(t, err.Cause())
+	require.Nil(t, err.Unwrap())
+	require.Nil(t, err.Details())
+	require.False(t, err.HasTrait(Timeout()))
+	require.False(t, err.IsOfType(testType))
+	require.False(t, err.HasStackTrace())
+	require.Nil(t, err.RecoveredAt())
+
+	_, _, _, ok := err.Origin()
+	require.False(t, ok)
+
+	_, ok = err.Property(testInfoProperty2)
+	require.False(t, ok)
+
+	_, ok = err.OwnProperty(testInfoProperty2)
+	require.False(t, ok)
+
+	err.VisitProperties(func(Property, interface{}) {
+		require.Fail(t, "unexpected property")
+	})
+
+	var buf bytes.Buffer
+	n, writeErr := err.WriteTo(&buf)
+	require.NoError(t, writeErr)
+	require.Equal(t, int64(0), n)
+
+	require.Equal(t, "", err.Markdown())
+	require.Equal(t, "", err.FormatFor(AudienceDev))
+
+	data, jsonErr := err.MarshalJSON()
+	require.NoError(t, jsonErr)
+	require.Equal(t, "null", string(data))
+
+	data, truncated, jsonErr := err.MarshalJSONLimited(10)
+	require.NoError(t, jsonErr)
+	require.False(t, truncated)
+	require.Equal(t, "null", string(data))
+}
+
+func TestSafeFormat(t *testing.T) {
+	var typedNil *Error
+
+	require.Equal(t, "", SafeFormat(nil))
+	require.Equal(t, "", SafeFormat(typedNil))
+	require.Equal(t, "test", SafeFormat(errors.New("test")))
+	require.Equal(t, fmt.Sprintf("%+v", testTypeSilent.New("test")), SafeFormat(testTypeSilent.New("test")))
+	require.Contains(t, SafeFormat(testType.New("test")), "TestSafeFormat", SafeFormat(testType.New("test")))
+}
@@ -0,0 +1,117 @@
+package errorxtest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/joomcode/errorx"
+)
+
+// Diff compares two errors field by field: type, message, traits and dynamic properties, and then the same for their causes.
+// Each field is printed on a line of its own, and a mismatch is marked in a manner of a unified diff:
+// an expected value is prefixed with "-", and an actual value with "+". Stack traces are not compared.
+// Returns an empty string if there is no mismatch.
+func Diff(expected, actual error) string {
+	var lines []string
+	mismatch := false
+
+	for level := 0; expected != nil || actual != nil; level++ {
+		prefix := strings.Repeat("cause.", level)
+		expectedFields, actualFields := describeFields(expected), describeFields(actual)
+
+		for _, name := range fieldNames(expectedFields, actualFields) {
+			expectedValue, expectedOk := expectedFields[name]
+			actualValue, actualOk := actualFields[name]
+			if expectedOk && actualOk && expectedValue == actualValue {
+				lines = append(lines, "  "+prefix+name+": "+expectedValue)
+				continue
+			}
+
+			mismatch = true
+			if expectedOk {
+				lines = append(lines, "- "+prefix+name+": "+expectedValue)
+			}
+			if actualOk {
+				lines = append(lines, "+ "+prefix+name+": "+actualValue)
+			}
+		}
+
+		expected, actual = causeOf(expected), causeOf(actual)
+	}
+
+	if !mismatch {
+		return ""
+	}
+	return strings.Join(lines, "\n")
+}
+
+// describeFields lists the comparable fields of a single level of an error chain, with no regard for its cause
+func describeFields(err error) map[string]string {
+	fields := make(map[string]string)
+	if err == nil {
+		return fields
+	}
+
+	typedErr := errorx.Cast(err)
+	if typedErr == nil {
+		fields["type"] = fmt.Sprintf("%T", err)
+		fields["message"] = fmt.Sprintf("%q", err.Error())
+		return fields
+	}
+
+	fields["type"] = typedErr.Type().FullName()
+	fields["message"] = fmt.Sprintf("%q", typedErr.Message())
+	fields["traits"] = "[" + strings.Join(typedErr.Type().TraitNames(), ", ") + "]"
+
+	cause := errorx.Cast(typedErr.Cause())
+	typedErr.VisitProperties(func(key errorx.Property, value interface{}) {
+		// a property visible through a transparent wrap is described once, at the level of the cause
+		if cause != nil {
+			if causeValue, ok := cause.Property(key); ok && reflect.DeepEqual(causeValue, value) {
+				return
+			}
+		}
+
+		fields["property "+key.Label()] = key.FormatValue(value)
+	})
+
+	return fields
+}
+
+// fieldNames orders the fields of both errors: type, message and traits go first, then properties sorted by label
+func fieldNames(a, b map[string]string) []string {
+	names := []string{"type", "message", "traits"}
+	var properties []string
+	for _, fields := range []map[string]string{a, b} {
+		for name := range fields {
+			if strings.HasPrefix(name, "property ") && !containsString(properties, name) {
+				properties = append(properties, name)
+			}
+		}
+	}
+
+	sort.Strings(properties)
+	return append(names, properties...)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func causeOf(err error) error {
+	if typedErr := errorx.Cast(err); typedErr != nil {
+		return typedErr.Cause()
+	}
+
+	if wrapper, ok := err.(interface{ Unwrap() error }); ok {
+		return wrapper.Unwrap()
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package errorxtest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joomcode/errorx"
+)
+
+var (
+	testPropertyID     = errorx.RegisterPrintableProperty("id")
+	testPropertyRegion = errorx.RegisterPrintableProperty("region")
+)
+
+func TestDiff(t *testing.T) {
+	t.Run("SingleProperty", func(t *testing.T) {
+		expected := testType.New("test").WithProperty(testPropertyID, 1).WithProperty(testPropertyRegion, "eu")
+		actual := testType.New("test").WithProperty(testPropertyID, 2).WithProperty(testPropertyRegion, "eu")
+
+		require.Equal(t, `  type: errorxtest.foo
+  message: "test"
+  traits: []
+- property id: 1
++ property id: 2
+  property region: eu`, Diff(expected, actual))
+	})
+
+	t.Run("Cause", func(t *testing.T) {
+		expected := errorx.Decorate(testType.Wrap(errors.New("boo"), "wrapped"), "decorated")
+		actual := errorx.Decorate(testSubtype.Wrap(errors.New("boo"), "wrapped"), "decorated")
+
+		require.Equal(t, `- type: errorxtest.foo
++ type: errorxtest.foo.bar
+  message: "decorated"
+  traits: []
+- cause.type: errorxtest.foo
++ cause.type: errorxtest.foo.bar
+  cause.message: "wrapped"
+  cause.traits: []
+  cause.cause.type: *errors.errorString
+  cause.cause.message: "boo"`, Diff(expected, actual))
+	})
+
+	t.Run("MissingCause", func(t *testing.T) {
+		diff := Diff(testType.Wrap(errors.New("boo"), "test"), testType.New("test"))
+		require.Contains(t, diff, "- cause.type: *errors.errorString", diff)
+		require.NotContains(t, diff, "+ cause.", diff)
+	})
+
+	t.Run("Equal", func(t *testing.T) {
+		require.Empty(t, Diff(testType.New("test"), testType.New("test")))
+		require.Empty(t, Diff(nil, nil))
+	})
+}
+
+func TestAssertEqual(t *testing.T) {
+	t.Run("Equal", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.True(t, AssertEqual(rec, testType.New("test").WithProperty(testPropertyID, 1), testType.New("test").WithProperty(testPropertyID, 1)))
+		require.Empty(t, rec.failures)
+	})
+
+	t.Run("Ignored", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.True(t, AssertEqual(rec, testType.New("test").WithProperty(testPropertyID, 1), testType.New("test").WithProperty(testPropertyID, 2), testPropertyID))
+		require.Empty(t, rec.failures)
+	})
+
+	t.Run("Different", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.False(t, AssertEqual(rec, testType.New("test").WithProperty(testPropertyID, 1), testType.New("test").WithProperty(testPropertyID, 2)))
+		require.Len(t, rec.failures, 1)
+		require.Contains(t, rec.failures[0], "- property id: 1\n+ property id: 2")
+	})
+}
@@ -0,0 +1,102 @@
+// Package errorxtest provides test assertions for errorx errors.
+package errorxtest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/joomcode/errorx"
+)
+
+// AssertType checks that an error is of the expected type, see errorx.IsOfType().
+// The transparency rules are respected, so that a type under an opaque wrap is not recognised.
+// Returns true if the check succeeded, otherwise marks a test as failed and prints the actual error chain.
+func AssertType(t testing.TB, err error, expected *errorx.Type) bool {
+	t.Helper()
+
+	if errorx.IsOfType(err, expected) {
+		return true
+	}
+
+	t.Errorf("expected error of type %s, actual chain: %s", expected.FullName(), describeChain(err))
+	return false
+}
+
+// AssertContainsType checks that an error of the expected type is present anywhere in an error chain.
+// Unlike AssertType, this disregards opaque wrap, and also looks into every branch of a joined error, such as MultiError.
+// This is useful to verify that a low level error is preserved as a cause, not how it is seen by the caller.
+// Returns true if the check succeeded, otherwise marks a test as failed and prints the actual error chain.
+func AssertContainsType(t testing.TB, err error, expected *errorx.Type) bool {
+	t.Helper()
+
+	found := false
+	walkChain(err, func(e error) {
+		if typedErr := errorx.Cast(e); typedErr != nil && typedErr.Type().IsOfType(expected) {
+			found = true
+		}
+	})
+
+	if found {
+		return true
+	}
+
+	t.Errorf("expected error of type %s somewhere in chain, actual chain: %s", expected.FullName(), describeChain(err))
+	return false
+}
+
+// AssertEqual checks that two errors are equal, disregarding the values of provided properties, see errorx.EqualIgnoring().
+// Returns true if the check succeeded, otherwise marks a test as failed and prints the difference between the errors, see Diff().
+func AssertEqual(t testing.TB, expected, actual error, ignore ...errorx.Property) bool {
+	t.Helper()
+
+	if errorx.EqualIgnoring(expected, actual, ignore...) {
+		return true
+	}
+
+	diff := Diff(expected, actual)
+	if diff == "" {
+		// say, underlying errors differ, which are not a part of a diff
+		diff = fmt.Sprintf("- %v\n+ %v", expected, actual)
+	}
+
+	t.Errorf("errors are not equal:\n%s", diff)
+	return false
+}
+
+// walkChain visits an error and all of its causes, depth first, including every branch of a joined error.
+func walkChain(err error, visit func(error)) {
+	if err == nil {
+		return
+	}
+
+	visit(err)
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			walkChain(inner, visit)
+		}
+	case interface{ Unwrap() error }:
+		walkChain(e.Unwrap(), visit)
+	}
+}
+
+func describeChain(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+
+	var levels []string
+	walkChain(err, func(e error) {
+		switch typedErr := e.(type) {
+		case *errorx.Error:
+			levels = append(levels, fmt.Sprintf("%s %q", typedErr.Type().FullName(), typedErr.Message()))
+		case *errorx.MultiError:
+			levels = append(levels, fmt.Sprintf("multi %q", typedErr.Message()))
+		default:
+			levels = append(levels, fmt.Sprintf("%T %q", e, e.Error()))
+		}
+	})
+
+	return "[" + strings.Join(levels, " -> ") + "]"
+}
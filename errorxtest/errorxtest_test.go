@@ -0,0 +1,84 @@
+package errorxtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joomcode/errorx"
+)
+
+var (
+	testNamespace = errorx.NewNamespace("errorxtest")
+	testType      = testNamespace.NewType("foo")
+	testSubtype   = testType.NewSubtype("bar")
+	testOtherType = testNamespace.NewType("other")
+)
+
+func TestAssertType(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.True(t, AssertType(rec, errorx.Decorate(testSubtype.New("test"), "decorated"), testType))
+		require.Empty(t, rec.failures)
+	})
+
+	t.Run("Opaque", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.False(t, AssertType(rec, testOtherType.Wrap(testType.New("test"), "wrapped"), testType))
+		require.Len(t, rec.failures, 1)
+		require.Contains(t, rec.failures[0], "errorxtest.other")
+	})
+}
+
+func TestAssertContainsType(t *testing.T) {
+	t.Run("ThreeLevelsDown", func(t *testing.T) {
+		err := errorx.InternalError.Wrap(
+			errorx.Decorate(
+				testOtherType.Wrap(testSubtype.New("root"), "middle"),
+				"decorated"),
+			"top")
+
+		rec := &recordingT{TB: t}
+		require.True(t, AssertContainsType(rec, err, testType))
+		require.Empty(t, rec.failures)
+	})
+
+	t.Run("JoinedBranch", func(t *testing.T) {
+		err := errorx.DecorateEach("batch",
+			testOtherType.New("first"),
+			fmt.Errorf("second: %w", testType.New("third")))
+
+		rec := &recordingT{TB: t}
+		require.True(t, AssertContainsType(rec, err, testType))
+		require.Empty(t, rec.failures)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := testOtherType.Wrap(errors.New("boo"), "wrapped")
+
+		rec := &recordingT{TB: t}
+		require.False(t, AssertContainsType(rec, err, testType))
+		require.Len(t, rec.failures, 1)
+		require.Contains(t, rec.failures[0], `[errorxtest.other "wrapped" -> *errors.errorString "boo"]`)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		rec := &recordingT{TB: t}
+		require.False(t, AssertContainsType(rec, nil, testType))
+		require.Contains(t, rec.failures[0], "<nil>")
+	})
+}
+
+// recordingT records failures instead of failing the actual test
+type recordingT struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingT) Helper() {}
+
+func (r *recordingT) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
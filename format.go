@@ -0,0 +1,130 @@
+package errorx
+
+import (
+	"sync/atomic"
+)
+
+// SetMaxPrintedCauseDepth limits a number of cause levels included in a full (%+v) output of an error.
+// Outermost error and its stack trace are always printed, deeper causes are replaced with a note on how many were omitted.
+// This may come in handy if errors with very long chains of causes flood the log.
+// Zero, which is the default, means no limit. Other outputs, such as Error(), as well as any programmatic access, are not affected.
+func SetMaxPrintedCauseDepth(depth int) {
+	atomic.StoreInt32(&formatSettings.maxCauseDepth, int32(depth))
+}
+
+// SetMaxMessageLength limits a length of each message in an error output, including the messages of its causes.
+// Message which is longer than a limit is cut to the limit and marked with an ellipsis. Length is measured in runes.
+// This may come in handy if some messages are excessively long, say, if they contain a full SQL query.
+// Zero, which is the default, means no limit. Message() and any other programmatic access are not affected.
+func SetMaxMessageLength(length int) {
+	atomic.StoreInt32(&formatSettings.maxMessageLength, int32(length))
+}
+
+// SetCauseSeparator changes a delimiter between an error and its cause in a one-line output, such as Error().
+// This may be needed to match an existing log format. Full (%+v) output is not affected and always uses the default.
+// Empty separator restores the default, which is ", cause: ".
+func SetCauseSeparator(separator string) {
+	formatSettings.causeSeparator.Store(separator)
+}
+
+// SetShowAllProperties makes a full (%+v) output of an error include all of its dynamic properties, not only the printable ones.
+// Values of non-printable properties are rendered as with %v format. Properties errorx keeps for itself, such as a public message, are never included.
+// This is purely a debug aid, as a non-printable property may hold sensitive data, and it is disabled by default.
+// Other outputs, such as Error(), are not affected.
+func SetShowAllProperties(show bool) {
+	value := int32(0)
+	if show {
+		value = 1
+	}
+	atomic.StoreInt32(&formatSettings.showAllProperties, value)
+}
+
+// CausePrintMode defines how the causes of an error are included in a full (%+v) output, see SetCausePrintMode.
+type CausePrintMode int32
+
+const (
+	// CausePrintModeFull prints the messages of all causes, followed by a stack trace along with the stack traces of the causes,
+	// if they were collected with EnhanceStackTrace() or the like; this is the default
+	CausePrintModeFull CausePrintMode = 0
+	// CausePrintModeMessagesThenOuterStack prints the messages of all causes, followed by the outermost stack trace only
+	CausePrintModeMessagesThenOuterStack CausePrintMode = 1
+	// CausePrintModeOuterOnly prints the message of an outermost error with a note on a number of causes, and its stack trace only
+	CausePrintModeOuterOnly CausePrintMode = 2
+)
+
+// SetCausePrintMode changes how the causes of an error are included in a full (%+v) output, to suit a logging practice.
+// The outermost stack trace is always printed in its entirety, even if some of its frames are shared with the stack trace of a cause.
+// Default is CausePrintModeFull. Other outputs, such as Error(), are not affected.
+func SetCausePrintMode(mode CausePrintMode) {
+	atomic.StoreInt32(&formatSettings.causePrintMode, int32(mode))
+}
+
+const defaultCauseSeparator = ", cause: "
+
+var formatSettings = struct {
+	maxCauseDepth     int32
+	maxMessageLength  int32
+	showAllProperties int32
+	causePrintMode    int32
+	causeSeparator    atomic.Value
+}{}
+
+// snapshotFormatSettings captures all the settings of an error output, see SnapshotRegistry.
+func snapshotFormatSettings() func() {
+	maxCauseDepth := atomic.LoadInt32(&formatSettings.maxCauseDepth)
+	maxMessageLength := atomic.LoadInt32(&formatSettings.maxMessageLength)
+	showAllProperties := atomic.LoadInt32(&formatSettings.showAllProperties)
+	causePrintMode := atomic.LoadInt32(&formatSettings.causePrintMode)
+	causeSeparator, _ := formatSettings.causeSeparator.Load().(string)
+	includeSourceLines := atomic.LoadInt32(&sourceLinesEnabled)
+	style := atomic.LoadInt32(&stackTraceStyle)
+
+	return func() {
+		atomic.StoreInt32(&formatSettings.maxCauseDepth, maxCauseDepth)
+		atomic.StoreInt32(&formatSettings.maxMessageLength, maxMessageLength)
+		atomic.StoreInt32(&formatSettings.showAllProperties, showAllProperties)
+		atomic.StoreInt32(&formatSettings.causePrintMode, causePrintMode)
+		formatSettings.causeSeparator.Store(causeSeparator)
+		atomic.StoreInt32(&sourceLinesEnabled, includeSourceLines)
+		atomic.StoreInt32(&stackTraceStyle, style)
+	}
+}
+
+func causePrintMode() CausePrintMode {
+	return CausePrintMode(atomic.LoadInt32(&formatSettings.causePrintMode))
+}
+
+func showAllProperties() bool {
+	return atomic.LoadInt32(&formatSettings.showAllProperties) != 0
+}
+
+func causeSeparator() string {
+	if separator, ok := formatSettings.causeSeparator.Load().(string); ok && separator != "" {
+		return separator
+	}
+	return defaultCauseSeparator
+}
+
+func printedCauseDepth() int {
+	if depth := atomic.LoadInt32(&formatSettings.maxCauseDepth); depth > 0 {
+		return int(depth)
+	}
+	return -1
+}
+
+func truncateMessage(message string) string {
+	length := int(atomic.LoadInt32(&formatSettings.maxMessageLength))
+	if length <= 0 || len(message) <= length {
+		return message
+	}
+
+	runes := 0
+	for i := range message {
+		if runes == length {
+			return message[:i] + "..."
+		}
+		runes++
+	}
+
+	return message
+}
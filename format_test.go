@@ -0,0 +1,181 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaxPrintedCauseDepth(t *testing.T) {
+	err := testTypeSilent.New("level 4")
+	err = testTypeBar1.Wrap(err, "level 3")
+	err = Decorate(err, "level 2")
+	err = testTypeBar2.Wrap(err, "level 1")
+	err = Decorate(err, "level 0")
+
+	t.Run("Unlimited", func(t *testing.T) {
+		require.Equal(t, "level 0, cause: foo.bar2: level 1, cause: level 2, cause: foo.bar1: level 3, cause: foo.bar.silent: level 4", firstLine(fmt.Sprintf("%+v", err)))
+	})
+
+	t.Run("Limited", func(t *testing.T) {
+		SetMaxPrintedCauseDepth(2)
+		defer SetMaxPrintedCauseDepth(0)
+
+		require.Equal(t, "level 0, cause: foo.bar2: level 1, cause: level 2 ...(2 more causes)", firstLine(fmt.Sprintf("%+v", err)))
+		require.Equal(t, "level 0, cause: foo.bar2: level 1, cause: level 2, cause: foo.bar1: level 3, cause: foo.bar.silent: level 4", err.Error())
+		require.Equal(t, "foo.bar1: level 3, cause: foo.bar.silent: level 4", firstLine(fmt.Sprintf("%+v", err.Cause().(*Error).Cause().(*Error).Cause())))
+	})
+
+	t.Run("StackTrace", func(t *testing.T) {
+		SetMaxPrintedCauseDepth(1)
+		defer SetMaxPrintedCauseDepth(0)
+
+		err := Decorate(Decorate(createErrorFuncInStackTrace(testType), "inner"), "outer")
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "outer, cause: inner ...(1 more causes)\n at ", output)
+		require.Contains(t, output, "createErrorFuncInStackTrace", output)
+	})
+}
+
+func firstLine(output string) string {
+	return strings.SplitN(output, "\n", 2)[0]
+}
+
+func TestMaxMessageLength(t *testing.T) {
+	SetMaxMessageLength(5)
+	defer SetMaxMessageLength(0)
+
+	t.Run("Short", func(t *testing.T) {
+		require.Equal(t, "foo.bar: short", testType.New("short").Error())
+	})
+
+	t.Run("Long", func(t *testing.T) {
+		err := testType.New("longer message")
+		require.Equal(t, "foo.bar: longe...", err.Error())
+		require.Equal(t, "foo.bar: longe...", fmt.Sprintf("%v", err))
+		require.Equal(t, "foo.bar: longe...", firstLine(fmt.Sprintf("%+v", err)))
+		require.Equal(t, "longer message", err.Message())
+	})
+
+	t.Run("Multibyte", func(t *testing.T) {
+		require.Equal(t, "foo.bar: приве", testType.New("приве").Error())
+		require.Equal(t, "foo.bar: приве...", testType.New("приветы").Error())
+		require.Equal(t, "foo.bar: 日本語テキ...", testType.New("日本語テキスト").Error())
+	})
+
+	t.Run("Cause", func(t *testing.T) {
+		err := Decorate(testType.Wrap(errors.New("SELECT * FROM users"), "query"), "handler")
+		require.Equal(t, "handl..., cause: foo.bar: query, cause: SELEC...", err.Error())
+	})
+
+	t.Run("Property", func(t *testing.T) {
+		err := testType.New("longer message").WithProperty(testInfoProperty2, "longer value")
+		require.Equal(t, "foo.bar: longe... {prop2: longer value}", err.Error())
+	})
+}
+
+func TestCauseSeparator(t *testing.T) {
+	err := Decorate(testType.Wrap(errors.New("root"), "middle"), "top")
+
+	t.Run("Custom", func(t *testing.T) {
+		SetCauseSeparator(" -> ")
+		defer SetCauseSeparator("")
+
+		require.Equal(t, "top -> foo.bar: middle -> root", err.Error())
+		require.Equal(t, "top -> foo.bar: middle -> root", fmt.Sprintf("%v", err))
+		require.Equal(t, "top, cause: foo.bar: middle, cause: root", firstLine(fmt.Sprintf("%+v", err)))
+	})
+
+	t.Run("Reset", func(t *testing.T) {
+		SetCauseSeparator(" -> ")
+		SetCauseSeparator("")
+
+		require.Equal(t, "top, cause: foo.bar: middle, cause: root", err.Error())
+	})
+}
+
+func TestShowAllProperties(t *testing.T) {
+	err := testType.New("test").
+		WithProperty(testProperty0, 42).
+		WithProperty(testInfoProperty2, "printable").
+		WithUnderlyingErrors(errors.New("hidden"))
+
+	t.Run("Disabled", func(t *testing.T) {
+		require.Equal(t, "foo.bar: test {prop2: printable} (hidden: hidden)", firstLine(fmt.Sprintf("%+v", err)))
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		SetShowAllProperties(true)
+		defer SetShowAllProperties(false)
+
+		require.Equal(t, "foo.bar: test {prop2: printable, test0: 42} (hidden: hidden)", firstLine(fmt.Sprintf("%+v", err)))
+		require.Equal(t, "foo.bar: test {test0: 42}", firstLine(fmt.Sprintf("%+v", testType.New("test").WithProperty(testProperty0, 42))))
+		require.Equal(t, "foo.bar: test {prop2: printable} (hidden: hidden)", err.Error())
+	})
+
+	t.Run("Internal", func(t *testing.T) {
+		SetShowAllProperties(true)
+		defer SetShowAllProperties(false)
+
+		withMessage := NewErrorBuilder(testType).WithPublicMessage("public").Create()
+		require.Equal(t, "foo.bar", firstLine(fmt.Sprintf("%+v", withMessage)))
+		require.Equal(t, "foo.bar: public", firstLine(fmt.Sprintf("%+v", withMessage.Public())))
+
+		recovered := handlePanicOf(func() { panic("boom") })
+		require.Equal(t, "common.panic: boom", firstLine(fmt.Sprintf("%+v", recovered)))
+		recovered.VisitProperties(func(Property, interface{}) {
+			require.Fail(t, "unexpected property")
+		})
+		require.True(t, EqualIgnoring(recovered, handlePanicOf(func() { panic("boom") })))
+	})
+}
+
+func TestCausePrintMode(t *testing.T) {
+	// a stack trace enhanced in the same goroutine, so that its last frames are shared with the cause
+	inner := withFixedStackTrace(testType.New("connection refused"),
+		fixedFrame{"main.dial", "/src/app/db.go", 12},
+		fixedFrame{"main.loadUser", "/src/app/user.go", 42},
+		fixedFrame{"main.main", "/src/app/main.go", 10},
+	)
+	outer := withFixedStackTrace(testSubtype0.Wrap(inner, "failed to load user"),
+		fixedFrame{"main.handle", "/src/app/handler.go", 7},
+		fixedFrame{"main.main", "/src/app/main.go", 10},
+	)
+	outer.stackTrace.causeStackTrace = inner.stackTrace
+	outer.stackTrace.frames = outer.stackTrace.frames[:1]
+	outer.stackTrace.cropped = 1
+
+	tests := []struct {
+		name   string
+		mode   CausePrintMode
+		golden string
+	}{
+		{"Full", CausePrintModeFull, "cause_print_mode_full.golden"},
+		{"MessagesThenOuterStack", CausePrintModeMessagesThenOuterStack, "cause_print_mode_messages_then_outer_stack.golden"},
+		{"OuterOnly", CausePrintModeOuterOnly, "cause_print_mode_outer_only.golden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetCausePrintMode(tt.mode)
+			defer SetCausePrintMode(CausePrintModeFull)
+
+			assertGolden(t, tt.golden, fmt.Sprintf("%+v", outer))
+			require.Equal(t, "foo.bar.internal: failed to load user, cause: foo.bar: connection refused", outer.Error())
+		})
+	}
+
+	t.Run("Unresolved", func(t *testing.T) {
+		SetCausePrintMode(CausePrintModeMessagesThenOuterStack)
+		defer SetCausePrintMode(CausePrintModeFull)
+
+		err := EnhanceStackTrace(testType.New("test"), "enhanced")
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "----", output)
+		require.NotContains(t, output, "duplicated frames", output)
+		require.Contains(t, output, "TestCausePrintMode", output)
+	})
+}
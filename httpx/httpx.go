@@ -0,0 +1,107 @@
+// Package httpx provides a translation of HTTP responses of downstream services into errorx errors.
+package httpx
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/joomcode/errorx"
+)
+
+var (
+	// Errors is a namespace for client errors of HTTP responses
+	Errors = errorx.NewNamespace("http")
+
+	// ClientError is a type for a 4xx response without a more specific type
+	ClientError = Errors.NewType("client_error")
+	// BadRequest is a type for 400 response
+	BadRequest = ClientError.NewSubtype("bad_request")
+	// NotFound is a type for 404 and 410 responses
+	NotFound = ClientError.NewSubtype("not_found", errorx.NotFound())
+	// RequestTimeout is a type for 408 response
+	RequestTimeout = ClientError.NewSubtype("request_timeout", errorx.Timeout())
+	// Conflict is a type for 409 response
+	Conflict = ClientError.NewSubtype("conflict", errorx.Duplicate())
+	// TooManyRequests is a type for 429 response
+	TooManyRequests = ClientError.NewSubtype("too_many_requests", errorx.Temporary())
+
+	// ServiceUnavailable is a type for 503 response, a subtype of errorx.ExternalError
+	ServiceUnavailable = errorx.ExternalError.NewSubtype("service_unavailable", errorx.Temporary())
+	// GatewayTimeout is a type for 504 response, a subtype of errorx.ExternalError
+	GatewayTimeout = errorx.ExternalError.NewSubtype("gateway_timeout", errorx.Timeout())
+)
+
+// MaxBodyPrefix is a maximum number of bytes of a response body to be included in an error message
+const MaxBodyPrefix = 512
+
+// FromResponse translates a non-2xx HTTP response into an error, returns nil for a 2xx response.
+// A type is chosen by a status: 4xx responses result in client errors, such as NotFound,
+// while 5xx responses, as well as other unexpected statuses, result in errorx.ExternalError or its subtype.
+// An error has a status property, see Status(), and is marked with a host of a request as an external system, see errorx.ExternalSystem().
+// The message is a prefix of a response body, at most MaxBodyPrefix bytes long, or a status text if the body is empty.
+// The body is read, but not closed, which remains a responsibility of the caller.
+func FromResponse(resp *http.Response) *errorx.Error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	externalSystem := ""
+	if resp.Request != nil && resp.Request.URL != nil {
+		externalSystem = resp.Request.URL.Host
+	}
+
+	return errorx.NewErrorBuilder(typeOfStatus(resp.StatusCode)).
+		WithConditionallyFormattedMessage(messageOf(resp)).
+		WithExternalSystem(externalSystem).
+		WithProperty(propertyStatus, resp.StatusCode).
+		Create()
+}
+
+// Status extracts an HTTP status of a response an error was created from, see FromResponse.
+func Status(err error) (int, bool) {
+	status, ok := errorx.ExtractProperty(err, propertyStatus)
+	if !ok {
+		return 0, false
+	}
+
+	return status.(int), true
+}
+
+var propertyStatus = errorx.RegisterPrintableProperty("httpStatus")
+
+func typeOfStatus(status int) *errorx.Type {
+	switch status {
+	case http.StatusBadRequest:
+		return BadRequest
+	case http.StatusNotFound, http.StatusGone:
+		return NotFound
+	case http.StatusRequestTimeout:
+		return RequestTimeout
+	case http.StatusConflict:
+		return Conflict
+	case http.StatusTooManyRequests:
+		return TooManyRequests
+	case http.StatusServiceUnavailable:
+		return ServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return GatewayTimeout
+	}
+
+	if status >= 400 && status < 500 {
+		return ClientError
+	}
+	return errorx.ExternalError
+}
+
+func messageOf(resp *http.Response) string {
+	if resp.Body != nil {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, MaxBodyPrefix))
+		if message := strings.TrimSpace(string(body)); message != "" {
+			return message
+		}
+	}
+
+	return resp.Status
+}
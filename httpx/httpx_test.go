@@ -0,0 +1,82 @@
+package httpx
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/joomcode/errorx"
+)
+
+func TestFromResponse(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		require.Nil(t, FromResponse(newResponse(t, http.StatusOK, "fine")))
+		require.Nil(t, FromResponse(newResponse(t, http.StatusNoContent, "")))
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		err := FromResponse(newResponse(t, http.StatusNotFound, "no such user\n"))
+		require.NotNil(t, err)
+		require.True(t, errorx.IsNotFound(err))
+		require.True(t, errorx.IsOfType(err, ClientError))
+		require.False(t, errorx.IsTemporary(err))
+		require.Equal(t, "http.client_error.not_found: no such user {httpStatus: 404, externalSystem: users-api}", err.Error())
+
+		status, ok := Status(errorx.Decorate(err, "failed to load user"))
+		require.True(t, ok)
+		require.Equal(t, http.StatusNotFound, status)
+
+		system, ok := errorx.ExternalSystem(err)
+		require.True(t, ok)
+		require.Equal(t, "users-api", system)
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "FromResponse()", output)
+		require.Contains(t, output, "TestFromResponse", output)
+	})
+
+	t.Run("ServiceUnavailable", func(t *testing.T) {
+		err := FromResponse(newResponse(t, http.StatusServiceUnavailable, ""))
+		require.NotNil(t, err)
+		require.True(t, errorx.IsTemporary(err))
+		require.True(t, errorx.IsOfType(err, errorx.ExternalError))
+		require.Equal(t, "common.external_error.service_unavailable: 503 Service Unavailable {httpStatus: 503, externalSystem: users-api}", err.Error())
+	})
+
+	t.Run("InternalServerError", func(t *testing.T) {
+		err := FromResponse(newResponse(t, http.StatusInternalServerError, "oops"))
+		require.NotNil(t, err)
+		require.True(t, errorx.IsOfType(err, errorx.ExternalError))
+		require.False(t, errorx.IsTemporary(err))
+	})
+
+	t.Run("OtherClientError", func(t *testing.T) {
+		err := FromResponse(newResponse(t, http.StatusForbidden, "denied"))
+		require.NotNil(t, err)
+		require.True(t, errorx.IsOfType(err, ClientError))
+		require.Equal(t, ClientError, err.Type())
+	})
+
+	t.Run("LongBody", func(t *testing.T) {
+		err := FromResponse(newResponse(t, http.StatusBadRequest, strings.Repeat("x", 2*MaxBodyPrefix)))
+		require.NotNil(t, err)
+		require.True(t, errorx.IsOfType(err, BadRequest))
+		require.Len(t, err.Message(), MaxBodyPrefix)
+	})
+}
+
+func newResponse(t *testing.T, status int, body string) *http.Response {
+	req, err := http.NewRequest(http.MethodGet, "http://users-api/users/1", nil)
+	require.NoError(t, err)
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
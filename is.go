@@ -0,0 +1,27 @@
+package errorx
+
+// IsAny checks if an error matches any of the targets, as with errors.Is(), see Error.Unwrap().
+// Targets are checked in order, and the check stops at the first match.
+// Before go 1.13, only the causes of errorx errors are looked through, and a match is an equality.
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if isError(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAll checks if an error matches all of the targets, as with errors.Is(), see Error.Unwrap().
+// Targets are checked in order, and the check stops at the first mismatch. Without targets, returns true.
+// Before go 1.13, only the causes of errorx errors are looked through, and a match is an equality.
+func IsAll(err error, targets ...error) bool {
+	for _, target := range targets {
+		if !isError(err, target) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package errorx
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsAny(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	err := Decorate(testType.Wrap(errA, "wrapped"), "decorated")
+
+	require.True(t, IsAny(err, errA, errB))
+	require.True(t, IsAny(err, errB, errA))
+	require.True(t, IsAny(errA, errA))
+	require.False(t, IsAny(err, errB, io.EOF))
+	require.False(t, IsAny(err))
+	require.False(t, IsAny(nil, errA))
+}
+
+func TestIsAll(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+	errC := errors.New("c")
+	err := DecorateMany("multiple", errA, Decorate(errB, "b"))
+
+	require.True(t, IsAll(err, errA))
+	require.False(t, IsAll(err, errA, errB), "underlying errors are not a part of the chain")
+	require.True(t, IsAll(Decorate(errA, "a"), errA))
+	require.False(t, IsAll(err, errA, errC))
+	require.False(t, IsAll(err, errC, errA))
+	require.True(t, IsAll(err))
+}
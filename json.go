@@ -0,0 +1,124 @@
+package errorx
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+var _ json.Marshaler = (*Error)(nil)
+
+// MarshalJSON implements the json.Marshaler interface.
+// Each level of an error chain is serialized as an object, with its cause nested under a "cause" key:
+//
+//	type		full name of the type of an opaque error, absent for a transparent wrapper
+//	message		message of this particular error, see Message()
+//	reason		machine-readable code of this particular error, see ErrorBuilder.WithReason()
+//	properties	printable dynamic properties of this particular error, by label
+//	details		structured payload of this particular error, see ErrorBuilder.WithDetails()
+//	cause		the cause; for a non-errorx cause, only a message is present
+//
+// Stack trace is not serialized. A property value which fails to be serialized is replaced with its %v representation,
+// and a value of a property with a custom stringer is always serialized as a result of that stringer, see RegisterPropertyWithStringer().
+func (e *Error) MarshalJSON() ([]byte, error) {
+	if e == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(e.jsonObject())
+}
+
+// MarshalJSONLimited serializes an error just as MarshalJSON does, but within a limit of maxBytes, when at all possible.
+// If the output does not fit, deeper causes are dropped one by one, and then the properties and details of the outermost error.
+// The outermost type, message and reason are always kept, even if the result still exceeds the limit.
+// An error where causes were dropped is marked with "truncated": true. Boolean result reports whether anything was dropped.
+func (e *Error) MarshalJSONLimited(maxBytes int) ([]byte, bool, error) {
+	if e == nil {
+		return []byte("null"), false, nil
+	}
+	object := e.jsonObject()
+	data, err := json.Marshal(object)
+	if err != nil || len(data) <= maxBytes {
+		return data, false, err
+	}
+
+	var levels []*errorJSON
+	for level := object; level != nil; level = level.Cause {
+		levels = append(levels, level)
+	}
+
+	for depth := len(levels) - 1; depth > 0; depth-- {
+		levels[depth-1].Cause = nil
+		levels[depth-1].Truncated = true
+
+		data, err = json.Marshal(object)
+		if err != nil || len(data) <= maxBytes {
+			return data, true, err
+		}
+	}
+
+	object.Properties = nil
+	object.Details = nil
+	data, err = json.Marshal(object)
+	return data, true, err
+}
+
+type errorJSON struct {
+	Type       string                     `json:"type,omitempty"`
+	Message    string                     `json:"message,omitempty"`
+	Reason     string                     `json:"reason,omitempty"`
+	Properties map[string]json.RawMessage `json:"properties,omitempty"`
+	Details    interface{}                `json:"details,omitempty"`
+	Cause      *errorJSON                 `json:"cause,omitempty"`
+	Truncated  bool                       `json:"truncated,omitempty"`
+}
+
+func (e *Error) jsonObject() *errorJSON {
+	object := &errorJSON{
+		Message:    e.message,
+		Properties: e.jsonProperties(),
+		Details:    e.details,
+	}
+
+	if !e.transparent {
+		object.Type = e.errorType.FullName()
+	}
+	if reason, ok := e.properties.get(propertyReason); ok {
+		object.Reason = reason.(string)
+	}
+
+	if typedCause := Cast(e.cause); typedCause != nil {
+		object.Cause = typedCause.jsonObject()
+	} else if e.cause != nil {
+		object.Cause = &errorJSON{Message: e.cause.Error()}
+	}
+
+	return object
+}
+
+func (e *Error) jsonProperties() map[string]json.RawMessage {
+	if e.printablePropertyCount == 0 {
+		return nil
+	}
+
+	properties := make(map[string]json.RawMessage, e.printablePropertyCount)
+	for m := e.properties; m != nil; m = m.next {
+		if !m.p.printable || m.p == propertyReason {
+			continue
+		}
+		if _, ok := properties[m.p.label]; ok {
+			continue
+		}
+
+		if m.p.stringer != nil {
+			properties[m.p.label], _ = json.Marshal(m.p.stringer(m.value))
+			continue
+		}
+
+		value, err := json.Marshal(m.value)
+		if err != nil {
+			value, _ = json.Marshal(fmt.Sprintf("%v", m.value))
+		}
+		properties[m.p.label] = value
+	}
+
+	return properties
+}
@@ -0,0 +1,153 @@
+package errorx
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		data, err := json.Marshal(testType.New("test"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{"type": "foo.bar", "message": "test"}`, string(data))
+	})
+
+	t.Run("Chain", func(t *testing.T) {
+		cause := testTypeBar1.Wrap(errors.New("root"), "middle").WithProperty(testInfoProperty2, 42)
+		data, err := json.Marshal(Decorate(cause, "top"))
+		require.NoError(t, err)
+		require.JSONEq(t, `{
+			"message": "top",
+			"cause": {
+				"type": "foo.bar1",
+				"message": "middle",
+				"properties": {"prop2": 42},
+				"cause": {"message": "root"}
+			}
+		}`, string(data))
+	})
+
+	t.Run("UnserializableProperty", func(t *testing.T) {
+		data, err := json.Marshal(testType.New("test").WithProperty(testInfoProperty2, make(chan int)))
+		require.NoError(t, err)
+		require.Contains(t, string(data), `"properties":{"prop2":"0x`)
+	})
+}
+
+func TestWithDetails(t *testing.T) {
+	type fieldViolation struct {
+		Field  string `json:"field"`
+		Reason string `json:"reason"`
+	}
+	type validationDetails struct {
+		Violations []fieldViolation `json:"violations"`
+	}
+
+	details := validationDetails{Violations: []fieldViolation{{Field: "email", Reason: "malformed"}}}
+	err := NewErrorBuilder(IllegalArgument).
+		WithConditionallyFormattedMessage("validation failed").
+		WithDetails(details).
+		Create()
+
+	t.Run("Details", func(t *testing.T) {
+		require.Equal(t, details, err.Details())
+		require.Equal(t, details, Decorate(err, "decorated").Details())
+		require.Nil(t, IllegalState.Wrap(err, "wrapped").Details())
+		require.Nil(t, IllegalArgument.New("test").Details())
+		require.Equal(t, "common.illegal_argument: validation failed", err.Error())
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, jsonErr := json.Marshal(err)
+		require.NoError(t, jsonErr)
+		require.JSONEq(t, `{
+			"type": "common.illegal_argument",
+			"message": "validation failed",
+			"details": {"violations": [{"field": "email", "reason": "malformed"}]}
+		}`, string(data))
+	})
+}
+
+func TestWithReason(t *testing.T) {
+	err := NewErrorBuilder(IllegalArgument).
+		WithConditionallyFormattedMessage("refresh token is expired").
+		WithReason("invalid_grant").
+		Create()
+
+	t.Run("Reason", func(t *testing.T) {
+		require.Equal(t, "common.illegal_argument: refresh token is expired {reason: invalid_grant}", err.Error())
+
+		reason, ok := Reason(Decorate(Decorate(err, "failed to refresh"), "failed to authorize"))
+		require.True(t, ok)
+		require.Equal(t, "invalid_grant", reason)
+
+		_, ok = Reason(IllegalState.Wrap(err, "opaque"))
+		require.False(t, ok)
+
+		_, ok = Reason(errors.New("test"))
+		require.False(t, ok)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, jsonErr := json.Marshal(Decorate(err.WithProperty(testInfoProperty2, 2), "failed to refresh"))
+		require.NoError(t, jsonErr)
+		require.JSONEq(t, `{
+			"message": "failed to refresh",
+			"cause": {
+				"type": "common.illegal_argument",
+				"message": "refresh token is expired",
+				"reason": "invalid_grant",
+				"properties": {"prop2": 2}
+			}
+		}`, string(data))
+	})
+}
+
+func TestMarshalJSONLimited(t *testing.T) {
+	err := testTypeBar1.Wrap(errors.New(strings.Repeat("root ", 50)), "middle").WithProperty(testInfoProperty2, 2)
+	err = Decorate(err, "decorated")
+	err = NewErrorBuilder(testType).
+		WithConditionallyFormattedMessage("top").
+		WithCause(err).
+		WithDetails(strings.Repeat("details ", 20)).
+		Create().
+		WithProperty(testInfoProperty3, 3)
+
+	full, jsonErr := json.Marshal(err)
+	require.NoError(t, jsonErr)
+
+	t.Run("Fits", func(t *testing.T) {
+		data, truncated, jsonErr := err.MarshalJSONLimited(len(full))
+		require.NoError(t, jsonErr)
+		require.False(t, truncated)
+		require.Equal(t, full, data)
+	})
+
+	t.Run("DeeperCausesDropped", func(t *testing.T) {
+		data, truncated, jsonErr := err.MarshalJSONLimited(len(full) - 1)
+		require.NoError(t, jsonErr)
+		require.True(t, truncated)
+		require.True(t, len(data) < len(full)-1)
+		require.NotContains(t, string(data), "root")
+		require.Contains(t, string(data), `"message":"middle","properties":{"prop2":2},"truncated":true`)
+		require.True(t, json.Valid(data))
+	})
+
+	t.Run("OuterPropertiesDropped", func(t *testing.T) {
+		data, truncated, jsonErr := err.MarshalJSONLimited(60)
+		require.NoError(t, jsonErr)
+		require.True(t, truncated)
+		require.JSONEq(t, `{"type": "foo.bar", "message": "top", "truncated": true}`, string(data))
+	})
+
+	t.Run("TypeAndMessageKept", func(t *testing.T) {
+		data, truncated, jsonErr := err.MarshalJSONLimited(10)
+		require.NoError(t, jsonErr)
+		require.True(t, truncated)
+		require.JSONEq(t, `{"type": "foo.bar", "message": "top", "truncated": true}`, string(data))
+	})
+}
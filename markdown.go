@@ -0,0 +1,70 @@
+package errorx
+
+import (
+	"strings"
+)
+
+// Markdown returns a representation of an error meant to be pasted into a bug report, a chat message or any other Markdown document.
+// It consists of a header with a type name in bold and a message complete with causes, a bullet list of printable properties,
+// and a stack trace in a fenced code block, if any. For example:
+//
+//	**common.illegal_state**: failed to load, cause: boom
+//
+//	- **id**: 42
+//
+//	```
+//	 at main.load()
+//		/src/main.go:10
+//	```
+//
+// A type name of a cause is omitted from the message when it is the one in the header, that is, through a transparent wrap.
+// A decorated non-errorx error has no type name, so the header is a message alone.
+// Properties follow the same visibility rules as with Property(). The output is for humans and is not meant to be parsed.
+func (e *Error) Markdown() string {
+	if e == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	typeName, message := GetTypeName(e), e.markdownMessage()
+	switch {
+	case typeName == "":
+		sb.WriteString(message)
+	case message == "":
+		sb.WriteString("**" + typeName + "**")
+	default:
+		sb.WriteString("**" + typeName + "**: " + message)
+	}
+	sb.WriteString("\n")
+
+	properties := e.visibleProperties(func(p Property) bool { return p.printable && !p.internal })
+	if len(properties) > 0 {
+		sb.WriteString("\n")
+		for _, m := range properties {
+			sb.WriteString("- **" + m.p.label + "**: " + m.p.FormatValue(m.value) + "\n")
+		}
+	}
+
+	if e.stackTrace != nil {
+		var trace strings.Builder
+		e.stackTrace.writeTo(&trace)
+		sb.WriteString("\n```\n")
+		sb.WriteString(strings.TrimLeft(trace.String(), "\n"))
+		sb.WriteString("\n```\n")
+	}
+
+	return sb.String()
+}
+
+func (e *Error) markdownMessage() string {
+	message := joinStringsIfNonEmpty(" ", truncateMessage(e.message), e.underlyingInfo())
+	if e.cause == nil {
+		return message
+	}
+
+	// a transparent wrap shares a type with its cause, which is already in the header, just as the properties of a cause are in the list
+	if typedCause := Cast(e.cause); typedCause != nil && e.transparent {
+		return joinStringsIfNonEmpty(causeSeparator(), message, typedCause.markdownMessage())
+	}
+	return joinStringsIfNonEmpty(causeSeparator(), message, truncateMessage(e.cause.Error()))
+}
@@ -0,0 +1,94 @@
+package errorx
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var updateGolden = flag.Bool("update", false, "update golden files")
+
+type fixedFrame struct {
+	function string
+	file     string
+	line     int
+}
+
+func (f fixedFrame) Function() string { return f.function }
+func (f fixedFrame) File() string     { return f.file }
+func (f fixedFrame) Line() int        { return f.line }
+func (f fixedFrame) Offset() uintptr  { return 0 }
+
+// withFixedStackTrace replaces a stack trace with predefined frames, so that an output does not depend on the build environment
+func withFixedStackTrace(err *Error, frames ...frame) *Error {
+	st := &stackTrace{pc: make([]uintptr, len(frames))}
+	st.resolveOnce.Do(func() {
+		st.frames = frames
+	})
+	st.resolveAllOnce.Do(func() {
+		st.allFrames = frames
+	})
+
+	err.stackTrace = st
+	return err
+}
+
+func TestMarkdown(t *testing.T) {
+	t.Run("Golden", func(t *testing.T) {
+		cause := testType.New("connection refused")
+		err := Decorate(cause, "failed to load user").
+			WithProperty(testInfoProperty2, 42).
+			WithProperty(testInfoProperty3, "eu-west")
+		err = withFixedStackTrace(err,
+			fixedFrame{"main.loadUser", "/src/app/user.go", 42},
+			fixedFrame{"main.main", "/src/app/main.go", 10},
+		)
+
+		assertGolden(t, "markdown.golden", err.Markdown())
+	})
+
+	t.Run("NoStackTrace", func(t *testing.T) {
+		err := testTypeSilent.Wrap(errors.New("boom"), "")
+		require.Equal(t, "**foo.bar.silent**: boom\n", err.Markdown())
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := testTypeBar1.Wrap(Decorate(testType.New("connection refused"), "failed to connect"), "failed to load user")
+		header := strings.SplitN(err.Markdown(), "\n", 2)[0]
+		require.Equal(t, "**foo.bar1**: failed to load user, cause: failed to connect, cause: foo.bar: connection refused", header)
+
+		err = Decorate(Decorate(testType.New("connection refused"), "failed to connect"), "failed to load user")
+		header = strings.SplitN(err.Markdown(), "\n", 2)[0]
+		require.Equal(t, "**foo.bar**: failed to load user, cause: failed to connect, cause: connection refused", header)
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		err := Decorate(errors.New("connection refused"), "failed to load user")
+		header := strings.SplitN(err.Markdown(), "\n", 2)[0]
+		require.Equal(t, "failed to load user, cause: connection refused", header)
+		require.NotContains(t, err.Markdown(), "synthetic")
+	})
+
+	t.Run("NonPrintableProperty", func(t *testing.T) {
+		err := testTypeSilent.New("test").WithProperty(testProperty0, "secret")
+		require.Equal(t, "**foo.bar.silent**: test\n", err.Markdown())
+	})
+}
+
+func assertGolden(t *testing.T, name string, actual string) {
+	t.Helper()
+
+	path := filepath.Join("testdata", name)
+	if *updateGolden {
+		require.NoError(t, ioutil.WriteFile(path, []byte(actual), 0644))
+	}
+
+	expected, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, string(expected), actual)
+}
@@ -0,0 +1,122 @@
+package errorx
+
+// MetricLabels returns labels to partition errors in metrics, such as a counter of errors by type, trait and dependency.
+// Type name is the same as with GetTypeName(), or "unknown" for an error without an errorx type.
+// Primary trait is a single most representative trait of an error, chosen in this order of priority:
+// NotFound, Timeout, Temporary, Duplicate, Expected. If an error possesses none of these, it is an empty string.
+// Custom traits are never chosen, so the cardinality of both labels is bounded by the number of registered types.
+// External system is the one which caused an error, see ExternalSystem(), or an empty string;
+// its cardinality is the responsibility of a caller which provides it, see ErrorBuilder.WithExternalSystem().
+func MetricLabels(err error) (typeName string, primaryTrait string, externalSystem string) {
+	typeName = GetTypeName(err)
+	if typeName == "" {
+		typeName = "unknown"
+	}
+
+	typedErr := Cast(err)
+	if typedErr == nil {
+		return typeName, "", ""
+	}
+
+	externalSystem, _ = ExternalSystem(typedErr)
+	for _, trait := range metricTraitPriority {
+		if typedErr.HasTrait(trait) {
+			return typeName, trait.Name(), externalSystem
+		}
+	}
+
+	return typeName, "", externalSystem
+}
+
+var metricTraitPriority = []Trait{
+	traitNotFound,
+	traitTimeout,
+	traitTemporary,
+	traitDuplicate,
+	traitExpected,
+}
+
+// Summary is an aggregate of a number of errors, say, those of a batch job, see Summarize.
+type Summary struct {
+	// Total is a number of errors, not counting nil ones
+	Total int
+	// ByType is a number of errors by type name, labeled as in MetricLabels()
+	ByType map[string]int
+	// ByTrait is a number of errors by each trait they possess, as with HasTrait(), labeled with Trait.Name()
+	ByTrait map[string]int
+	// Samples are the first few errors, in order, to be logged as an example
+	Samples []error
+}
+
+// Summarize tallies errors by type and by trait, and keeps a few samples, see Summary.
+// This is a shortcut to report the outcome of a batch in a single log message. Nil errors are skipped.
+// Error without an errorx type is counted under "unknown" type, and only has traits recognised by an adapter, see RegisterErrorAdapter.
+// Traits are checked just as with HasTrait() and MetricLabels(), so those of a non-errorx cause of a transparent wrap are counted too.
+func Summarize(errs []error) Summary {
+	summary := Summary{
+		ByType:  make(map[string]int),
+		ByTrait: make(map[string]int),
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		summary.Total++
+		typeName, _, _ := MetricLabels(err)
+		summary.ByType[typeName]++
+
+		for trait := range candidateTraits(err) {
+			if HasTrait(err, trait) {
+				summary.ByTrait[trait.Name()]++
+			}
+		}
+
+		if len(summary.Samples) < maxSummarySamples {
+			summary.Samples = append(summary.Samples, err)
+		}
+	}
+
+	return summary
+}
+
+const maxSummarySamples = 5
+
+// candidateTraits collects the traits an error may possess, to be checked with HasTrait():
+// those of the types along a chain of transparent and trait propagating wraps, and those recognised in a non-errorx error.
+func candidateTraits(err error) map[Trait]struct{} {
+	candidates := make(map[Trait]struct{})
+	addForeign := func(foreign error) {
+		candidates[traitNotFound] = struct{}{}
+		traits, _, _ := adaptError(foreign)
+		for _, trait := range traits {
+			candidates[trait] = struct{}{}
+		}
+	}
+
+	typedErr := Cast(err)
+	if typedErr == nil {
+		addForeign(err)
+		return candidates
+	}
+
+	for typedErr != nil {
+		for trait := range typedErr.errorType.traits {
+			candidates[trait] = struct{}{}
+		}
+
+		if !typedErr.transparent && len(typedErr.propagatedTraits) == 0 {
+			break
+		}
+
+		cause := typedErr.Cause()
+		next := Cast(cause)
+		if next == nil && cause != nil {
+			addForeign(cause)
+		}
+		typedErr = next
+	}
+
+	return candidates
+}
@@ -0,0 +1,105 @@
+package errorx
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricLabels(t *testing.T) {
+	metricsNamespace := NewNamespace("metrics")
+	notFoundTimeout := metricsNamespace.NewType("not_found_timeout", Timeout(), NotFound())
+	temporaryTimeout := metricsNamespace.NewType("temporary_timeout", Temporary(), Timeout())
+	temporaryDuplicate := metricsNamespace.NewType("temporary_duplicate", Duplicate(), Temporary())
+	custom := metricsNamespace.NewType("custom", RegisterTrait("custom"))
+
+	tests := []struct {
+		name           string
+		err            error
+		expectedType   string
+		expectedTrait  string
+		expectedSystem string
+	}{
+		{"NotFoundOverTimeout", notFoundTimeout.New("test"), "metrics.not_found_timeout", "not_found", ""},
+		{"TimeoutOverTemporary", temporaryTimeout.New("test"), "metrics.temporary_timeout", "timeout", ""},
+		{"TemporaryOverDuplicate", temporaryDuplicate.New("test"), "metrics.temporary_duplicate", "temporary", ""},
+		{"DuplicateOverExpected", NewDuplicate("key"), "common.duplicate_entry", "duplicate", ""},
+		{"Expected", IllegalArgument.New("test"), "common.illegal_argument", "expected", ""},
+		{"CustomTraitOnly", custom.New("test"), "metrics.custom", "", ""},
+		{"Decorated", Decorate(temporaryTimeout.New("test"), "decorated"), "metrics.temporary_timeout", "timeout", ""},
+		{"Opaque", InternalError.Wrap(notFoundTimeout.New("test"), "wrapped"), "common.internal_error", "", ""},
+		{"DecoratedForeign", Decorate(os.ErrNotExist, "decorated"), "unknown", "not_found", ""},
+		{"NonErrorx", errors.New("test"), "unknown", "", ""},
+		{"Nil", nil, "unknown", "", ""},
+		{"ExternalSystem", Decorate(TimeoutElapsed.NewWith("test", WithExternalSystemOpt("payments-api")), "decorated"), "common.timeout", "timeout", "payments-api"},
+		{"ExternalSystemBehindOpaqueWrap", InternalError.Wrap(ExternalError.NewWith("test", WithExternalSystemOpt("payments-api")), "wrapped"), "common.internal_error", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			typeName, trait, externalSystem := MetricLabels(tt.err)
+			require.Equal(t, tt.expectedType, typeName)
+			require.Equal(t, tt.expectedTrait, trait)
+			require.Equal(t, tt.expectedSystem, externalSystem)
+		})
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	t.Run("Mixed", func(t *testing.T) {
+		errs := []error{
+			NewDuplicate("a"),
+			NewDuplicate("b"),
+			Decorate(NewTimeout(0, "fetch"), "decorated"),
+			nil,
+			IllegalState.New("test"),
+			errors.New("test"),
+			os.ErrNotExist,
+		}
+
+		summary := Summarize(errs)
+		require.Equal(t, 6, summary.Total)
+		require.Equal(t, map[string]int{
+			"common.duplicate_entry": 2,
+			"common.timeout":         1,
+			"common.illegal_state":   1,
+			"unknown":                2,
+		}, summary.ByType)
+		require.Equal(t, map[string]int{
+			"duplicate": 2,
+			"expected":  2,
+			"timeout":   1,
+		}, summary.ByTrait)
+		require.Equal(t, []error{errs[0], errs[1], errs[2], errs[4], errs[5]}, summary.Samples)
+	})
+
+	t.Run("Foreign", func(t *testing.T) {
+		defer SnapshotRegistry()()
+		RegisterErrorAdapter(pgErrorAdapter)
+
+		err := Decorate(&os.PathError{Op: "open", Path: "/tmp/users", Err: os.ErrNotExist}, "load")
+		_, primaryTrait, _ := MetricLabels(err)
+		require.Equal(t, "not_found", primaryTrait)
+
+		summary := Summarize([]error{
+			err,
+			&pgError{code: "23505"},
+			Decorate(&pgError{code: "23505"}, "insert"),
+			&pgError{code: "40001"},
+		})
+		require.Equal(t, map[string]int{
+			"not_found": 1,
+			"duplicate": 2,
+		}, summary.ByTrait)
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		summary := Summarize(nil)
+		require.Equal(t, 0, summary.Total)
+		require.Empty(t, summary.ByType)
+		require.Empty(t, summary.ByTrait)
+		require.Empty(t, summary.Samples)
+	})
+}
@@ -0,0 +1,95 @@
+package errorx
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// MultiError is a composition of several independent errors under a common message.
+// Unlike DecorateMany, it does not elect one of the errors as a cause, so all of them are equal in formatting:
+// full (%+v) output contains the common message once, followed by each error complete with its own stack trace.
+// As MultiError possesses no type or traits of its own, type and trait checks should be performed over each of Errors().
+type MultiError struct {
+	message string
+	errs    []error
+}
+
+var _ fmt.Formatter = (*MultiError)(nil)
+
+// DecorateEach composes several errors under a common message, see MultiError.
+// Nil errors are ignored. If there are no errors, or all errors are nil, returns nil.
+// Note that the result is a pointer type; take care not to turn a nil result into a non-nil error interface value.
+func DecorateEach(message string, errs ...error) *MultiError {
+	errs = ignoreEmpty(errs)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &MultiError{
+		message: message,
+		errs:    errs,
+	}
+}
+
+// Message returns the common message of all errors.
+func (m *MultiError) Message() string {
+	return m.message
+}
+
+// Errors returns all composed errors.
+func (m *MultiError) Errors() []error {
+	return append([]error(nil), m.errs...)
+}
+
+// Unwrap returns all composed errors, for interoperability with standard library errors.Is() and errors.As().
+func (m *MultiError) Unwrap() []error {
+	return m.Errors()
+}
+
+// Error implements the error interface.
+// A result contains the common message followed by the messages of all composed errors, and does not contain stack traces.
+func (m *MultiError) Error() string {
+	messages := make([]string, 0, len(m.errs))
+	for _, err := range m.errs {
+		messages = append(messages, err.Error())
+	}
+
+	return joinStringsIfNonEmpty(": ", m.message, strings.Join(messages, "; "))
+}
+
+// Format implements the Formatter interface.
+// Supported verbs:
+//
+//	%s		simple message output
+//	%v		same as %s
+//	%+v		full output, with each error complete with its own stack trace
+func (m *MultiError) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			m.writeFull(s)
+			return
+		}
+		io.WriteString(s, m.Error())
+	case 's':
+		io.WriteString(s, m.Error())
+	}
+}
+
+func (m *MultiError) writeFull(w io.Writer) {
+	io.WriteString(w, m.message)
+	for i, err := range m.errs {
+		io.WriteString(w, "\n (")
+		io.WriteString(w, strconv.Itoa(i+1))
+		io.WriteString(w, " of ")
+		io.WriteString(w, strconv.Itoa(len(m.errs)))
+		io.WriteString(w, ") ")
+		if typedErr := Cast(err); typedErr != nil {
+			typedErr.WriteTo(w)
+		} else {
+			fmt.Fprintf(w, "%+v", err)
+		}
+	}
+}
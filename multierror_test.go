@@ -0,0 +1,55 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecorateEach(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		require.Nil(t, DecorateEach("validation failed"))
+		require.Nil(t, DecorateEach("validation failed", nil, nil))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		err := DecorateEach("validation failed", IllegalArgument.New("name is empty"), nil, errors.New("age is negative"))
+		require.Equal(t, "validation failed: common.illegal_argument: name is empty; age is negative", err.Error())
+		require.Equal(t, err.Error(), fmt.Sprintf("%v", err))
+		require.Len(t, err.Errors(), 2)
+		require.Equal(t, "validation failed", err.Message())
+	})
+
+	t.Run("DistinctStackTraces", func(t *testing.T) {
+		err := DecorateEach("validation failed", validateName(), validateAge(), validateEmail())
+		output := fmt.Sprintf("%+v", err)
+
+		require.Equal(t, 1, strings.Count(output, "validation failed"), output)
+		require.True(t, strings.HasPrefix(output, "validation failed\n (1 of 3) common.illegal_argument: name is empty\n at "), output)
+		require.Contains(t, output, "\n (2 of 3) common.illegal_argument: age is negative\n at ", output)
+		require.Contains(t, output, "\n (3 of 3) common.illegal_argument: email is malformed\n at ", output)
+
+		expected := map[string]int{
+			"validateName()":  0,
+			"validateAge()":   0,
+			"validateEmail()": 0,
+		}
+		checkStackTrace(t, output, expected)
+		require.Equal(t, 3, strings.Count(output, "TestDecorateEach"), output)
+	})
+}
+
+func validateName() error {
+	return IllegalArgument.New("name is empty")
+}
+
+func validateAge() error {
+	return IllegalArgument.New("age is negative")
+}
+
+func validateEmail() error {
+	return IllegalArgument.New("email is malformed")
+}
@@ -0,0 +1,47 @@
+package errorx
+
+// Option is a modification of an error under construction, to be used with Type.NewWith().
+// Each option is a shorthand for an ErrorBuilder method, and the same restrictions apply to the combinations of options.
+type Option func(ErrorBuilder) ErrorBuilder
+
+// WithCauseOpt provides an original cause for an error, see ErrorBuilder.WithCause().
+func WithCauseOpt(err error) Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithCause(err)
+	}
+}
+
+// WithPropertyOpt adds a dynamic property to an error, see ErrorBuilder.WithProperty().
+func WithPropertyOpt(key Property, value interface{}) Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithProperty(key, value)
+	}
+}
+
+// WithDetailsOpt attaches a structured payload to an error, see ErrorBuilder.WithDetails().
+func WithDetailsOpt(details interface{}) Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithDetails(details)
+	}
+}
+
+// WithPublicMessageOpt provides a message which is safe to be exposed to an outside observer, see ErrorBuilder.WithPublicMessage().
+func WithPublicMessageOpt(message string) Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithPublicMessage(message)
+	}
+}
+
+// WithExternalSystemOpt marks an error as caused by an external system, see ErrorBuilder.WithExternalSystem().
+func WithExternalSystemOpt(name string) Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithExternalSystem(name)
+	}
+}
+
+// WithoutStackTraceOpt prevents an error from collecting a stack trace, see ErrorBuilder.WithoutStackTrace().
+func WithoutStackTraceOpt() Option {
+	return func(eb ErrorBuilder) ErrorBuilder {
+		return eb.WithoutStackTrace()
+	}
+}
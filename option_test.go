@@ -0,0 +1,69 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWith(t *testing.T) {
+	t.Run("NoOptions", func(t *testing.T) {
+		err := testType.NewWith("100%")
+		require.Equal(t, "foo.bar: 100%", err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "NewWith()", output)
+		require.Contains(t, output, "TestNewWith", output)
+	})
+
+	t.Run("Combined", func(t *testing.T) {
+		cause := errors.New("connection reset")
+		err := testType.NewWith("charge failed",
+			WithCauseOpt(cause),
+			WithPropertyOpt(testInfoProperty2, 2),
+			WithPropertyOpt(testProperty0, 0),
+			WithPropertyOpt(testInfoProperty2, 22),
+			WithDetailsOpt("details"),
+			WithPublicMessageOpt("please retry"),
+			WithExternalSystemOpt("payments-api"),
+		)
+
+		require.True(t, IsOfType(err, testType))
+		require.Equal(t, cause, err.Cause())
+		require.Equal(t, "details", err.Details())
+		require.Equal(t, "please retry", err.Public().Message())
+		require.Equal(t, "foo.bar: charge failed {prop2: 22, externalSystem: payments-api}, cause: connection reset", err.Error())
+
+		property0, ok := err.Property(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 0, property0)
+
+		name, ok := ExternalSystem(err)
+		require.True(t, ok)
+		require.Equal(t, "payments-api", name)
+	})
+
+	t.Run("WithoutStackTrace", func(t *testing.T) {
+		err := testType.NewWith("silent", WithoutStackTraceOpt(), WithCauseOpt(errors.New("cause")))
+		require.NotContains(t, fmt.Sprintf("%+v", err), "TestNewWith", err)
+	})
+
+	t.Run("SharedOptions", func(t *testing.T) {
+		common := []Option{WithPropertyOpt(testInfoProperty2, 2)}
+		err1 := testType.NewWith("first", append(common, WithPropertyOpt(testInfoProperty3, 3))...)
+		err2 := testType.NewWith("second", common...)
+
+		require.Equal(t, "foo.bar: first {prop3: 3, prop2: 2}", err1.Error())
+		require.Equal(t, "foo.bar: second {prop2: 2}", err2.Error())
+	})
+
+	t.Run("IllegalCombination", func(t *testing.T) {
+		require.Panics(t, func() {
+			testType.NewWith("test", WithoutStackTraceOpt(), WithCauseOpt(errors.New("cause")), func(eb ErrorBuilder) ErrorBuilder {
+				return eb.EnhanceStackTrace()
+			})
+		})
+	})
+}
@@ -0,0 +1,71 @@
+// Package otelx provides an integration of errorx errors with OpenTelemetry tracing.
+//
+// It is a separate module, so that the dependency on OpenTelemetry is only brought to those who opt in.
+package otelx
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/joomcode/errorx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// AttributeErrorType is an attribute key for a full name of an error type
+	AttributeErrorType = attribute.Key("errorx.type")
+	// AttributeErrorTraits is an attribute key for a list of error trait names
+	AttributeErrorTraits = attribute.Key("errorx.traits")
+	// AttributePropertyPrefix is a prefix of attribute keys for printable error properties
+	AttributePropertyPrefix = "errorx.property."
+	// AttributeExceptionStacktrace is a standard attribute key for an exception stack trace
+	AttributeExceptionStacktrace = attribute.Key("exception.stacktrace")
+)
+
+// RecordError records an error on a span from the context, if there is one, and sets the span status to Error.
+// An exception event of the span receives the attributes for a type, traits and printable properties of errorx error,
+// as well as the full error output complete with a stack trace.
+// For a nil error, does nothing. Non-errorx errors are recorded with a stack trace attribute only.
+func RecordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.RecordError(err, trace.WithAttributes(Attributes(err)...))
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// Attributes returns attributes which describe an error, see RecordError.
+func Attributes(err error) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		AttributeExceptionStacktrace.String(fmt.Sprintf("%+v", err)),
+	}
+
+	typedErr := errorx.Cast(err)
+	if typedErr == nil {
+		return attributes
+	}
+
+	if typeName := errorx.GetTypeName(err); typeName != "" {
+		attributes = append(attributes, AttributeErrorType.String(typeName))
+	}
+
+	if traits := typedErr.Type().TraitNames(); len(traits) > 0 {
+		attributes = append(attributes, AttributeErrorTraits.StringSlice(traits))
+	}
+
+	typedErr.VisitProperties(func(key errorx.Property, value interface{}) {
+		if key.IsPrintable() {
+			attributes = append(attributes, attribute.String(AttributePropertyPrefix+key.Label(), key.FormatValue(value)))
+		}
+	})
+
+	return attributes
+}
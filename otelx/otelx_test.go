@@ -0,0 +1,90 @@
+package otelx
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/joomcode/errorx"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var (
+	testNamespace = errorx.NewNamespace("otelx")
+	testType      = testNamespace.NewType("test", errorx.Temporary(), errorx.Timeout())
+	testProperty  = errorx.RegisterPrintableProperty("user")
+	testSecret    = errorx.RegisterProperty("secret")
+)
+
+func TestRecordError(t *testing.T) {
+	t.Run("Errorx", func(t *testing.T) {
+		recorder, ctx, end := startSpan()
+		err := errorx.Decorate(testType.New("bad").WithProperty(testProperty, "alice").WithProperty(testSecret, "hidden"), "decorated")
+		RecordError(ctx, err)
+		end()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+		require.Equal(t, "decorated, cause: otelx.test: bad {user: alice}", spans[0].Status().Description)
+
+		events := spans[0].Events()
+		require.Len(t, events, 1)
+		attributes := attributeMap(events[0].Attributes)
+		require.Equal(t, "otelx.test", attributes[AttributeErrorType].AsString())
+		require.Equal(t, []string{"temporary", "timeout"}, attributes[AttributeErrorTraits].AsStringSlice())
+		require.Equal(t, "alice", attributes[attribute.Key(AttributePropertyPrefix+"user")].AsString())
+		require.NotContains(t, attributes, attribute.Key(AttributePropertyPrefix+"secret"))
+		require.Contains(t, attributes[AttributeExceptionStacktrace].AsString(), "TestRecordError")
+	})
+
+	t.Run("NonErrorx", func(t *testing.T) {
+		recorder, ctx, end := startSpan()
+		RecordError(ctx, errors.New("bad"))
+		end()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+
+		attributes := attributeMap(spans[0].Events()[0].Attributes)
+		require.NotContains(t, attributes, AttributeErrorType)
+		require.Equal(t, "bad", attributes[AttributeExceptionStacktrace].AsString())
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		recorder, ctx, end := startSpan()
+		RecordError(ctx, nil)
+		end()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 1)
+		require.Equal(t, codes.Unset, spans[0].Status().Code)
+		require.Empty(t, spans[0].Events())
+	})
+
+	t.Run("NoSpan", func(t *testing.T) {
+		require.NotPanics(t, func() {
+			RecordError(context.Background(), testType.New("bad"))
+		})
+	})
+}
+
+func startSpan() (*tracetest.SpanRecorder, context.Context, func()) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("otelx").Start(context.Background(), "test")
+	return recorder, ctx, func() { span.End() }
+}
+
+func attributeMap(attributes []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	result := make(map[attribute.Key]attribute.Value, len(attributes))
+	for _, kv := range attributes {
+		result[kv.Key] = kv.Value
+	}
+	return result
+}
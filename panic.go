@@ -1,6 +1,10 @@
 package errorx
 
-import "fmt"
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
 
 // Panic is an alternative to the built-in panic call.
 // When calling panic as a reaction to error, prefer this function over vanilla panic().
@@ -44,6 +48,105 @@ func ErrorFromPanic(recoverResult interface{}) (error, bool) {
 	return err, true
 }
 
+// Try calls fn and returns its result, unless fn panics, in which case the panic is recovered and returned as an error.
+// The error is that of HandlePanic(), which keeps the stack trace of the point of panic.
+// This may be used to turn a call of a panicky third-party code into a conventional error-returning one:
+//
+//	err := errorx.Try(func() error { return parser.MustParse(input) })
+func Try(fn func() error) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = HandlePanic(recovered)
+		}
+	}()
+
+	return fn()
+}
+
+// HandlePanic transforms a recover() result into a PanicError, so that it may be logged and handled as any other error.
+// Returns nil if there was no panic, that is, if the recovered value is nil.
+// An errorx error, either passed to panic() or to Panic(), is kept as a cause along with its original stack trace.
+// For any other value, a non-error value is used as a message, and a stack trace is that of the point of panic.
+// In either case, a point of recovery is recorded separately, see Error.RecoveredAt().
+//
+//	defer func() {
+//		if err := errorx.HandlePanic(recover()); err != nil {
+//			log.Printf("%+v", err)
+//		}
+//	}()
+func HandlePanic(recovered interface{}) *Error {
+	if recovered == nil {
+		return nil
+	}
+
+	var pc [stackTraceDepth]uintptr
+	recoveredAt, panicSite := splitAtPanic(pc[:runtime.Callers(2, pc[:])])
+
+	builder := NewErrorBuilder(PanicError).
+		WithProperty(propertyRecoveredAt, recoveredAt)
+
+	err, ok := ErrorFromPanic(recovered)
+	if !ok {
+		builder = builder.WithConditionallyFormattedMessage("%v", recovered)
+	} else {
+		builder = builder.WithCause(err)
+	}
+
+	// a stack trace collected in a deferred function is that of a panic, with the deferred function on top of it
+	if Cast(err) == nil && len(panicSite) > 0 {
+		builder = builder.withStackTrace(&stackTrace{pc: panicSite})
+	}
+	return builder.Create()
+}
+
+// RecoveredAt returns a stack trace of a point where a panic was recovered, for an error created by HandlePanic().
+// It starts with a deferred function which called HandlePanic(), and ends with a point of panic, which is excluded.
+// This tells apart a point where a panic was caught from a point where an error originated, that is, its stack trace.
+// Like a property, it is visible through a transparent wrap. For an error not derived from a panic, returns nil.
+func (e *Error) RecoveredAt() []runtime.Frame {
+	value, ok := e.Property(propertyRecoveredAt)
+	if !ok {
+		return nil
+	}
+
+	pc := value.([]uintptr)
+	if len(pc) == 0 {
+		return nil
+	}
+
+	result := make([]runtime.Frame, 0, len(pc))
+	visitFrames(pc, func(frame runtime.Frame) bool {
+		result = append(result, frame)
+		return true
+	})
+
+	return result
+}
+
+// splitAtPanic separates a stack trace collected in a deferred function into a part above a panic, and a part below it.
+// If there was no panic, all of the stack trace is above.
+func splitAtPanic(pc []uintptr) (recoveredAt []uintptr, panicSite []uintptr) {
+	recoveredAt = append([]uintptr(nil), pc...)
+	for i := range pc {
+		if fn := runtime.FuncForPC(pc[i] - 1); fn == nil || fn.Name() != "runtime.gopanic" {
+			continue
+		}
+
+		// a runtime error, such as a nil dereference, is raised by runtime functions, which are of no interest
+		panicSite = pc[i+1:]
+		for len(panicSite) > 0 {
+			if fn := runtime.FuncForPC(panicSite[0] - 1); fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+				break
+			}
+			panicSite = panicSite[1:]
+		}
+
+		return recoveredAt[:i], append([]uintptr(nil), panicSite...)
+	}
+
+	return recoveredAt, nil
+}
+
 func newPanicErrorWrapper(err error) *panicErrorWrapper {
 	return &panicErrorWrapper{
 		inner: NewErrorBuilder(panicPayloadWrap).
@@ -69,3 +172,5 @@ func (w *panicErrorWrapper) String() string {
 
 // Only required to transform panic into error while preserving the stack trace
 var panicPayloadWrap = syntheticErrors.NewType("panic").ApplyModifiers(TypeModifierTransparent)
+
+var propertyRecoveredAt = registerInternalProperty("recoveredAt")
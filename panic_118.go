@@ -0,0 +1,18 @@
+//go:build go1.18
+// +build go1.18
+
+package errorx
+
+// Try1 is a sibling of Try() for a function with a result, which is a zero value when fn panics.
+//
+//	value, err := errorx.Try1(func() (int, error) { return strconv.Atoi(input) })
+func Try1[T any](fn func() (T, error)) (result T, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			var zero T
+			result, err = zero, HandlePanic(recovered)
+		}
+	}()
+
+	return fn()
+}
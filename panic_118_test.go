@@ -0,0 +1,40 @@
+//go:build go1.18
+// +build go1.18
+
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTry1(t *testing.T) {
+	t.Run("Result", func(t *testing.T) {
+		result, err := Try1(func() (int, error) { return 42, nil })
+		require.NoError(t, err)
+		require.Equal(t, 42, result)
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cause := errors.New("bad")
+		result, err := Try1(func() (string, error) { return "partial", cause })
+		require.Equal(t, cause, err)
+		require.Equal(t, "partial", result)
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		result, err := Try1(func() (*int, error) {
+			panicInNestedFunc()
+			return new(int), nil
+		})
+		require.Nil(t, result)
+		require.True(t, IsOfType(err, PanicError))
+		require.True(t, Cast(err).HasStackTrace())
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "panicInNestedFunc", output)
+	})
+}
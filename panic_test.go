@@ -177,3 +177,169 @@ func mischiefAsPanic() {
 func mischiefProper() error {
 	return ExternalError.New("mischief")
 }
+
+func TestHandlePanic(t *testing.T) {
+	t.Run("NoPanic", func(t *testing.T) {
+		require.Nil(t, handlePanicOf(func() {}))
+		require.Nil(t, HandlePanic(nil))
+	})
+
+	t.Run("String", func(t *testing.T) {
+		err := handlePanicOf(func() { panic("boom") })
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, PanicError))
+		require.Equal(t, "common.panic: boom", err.Error())
+		require.Nil(t, err.Cause())
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "handlePanicOf", output)
+		require.NotContains(t, output, "HandlePanic()", output)
+	})
+
+	t.Run("Struct", func(t *testing.T) {
+		type payload struct {
+			Code int
+		}
+
+		err := handlePanicOf(func() { panic(payload{Code: 42}) })
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, PanicError))
+		require.Equal(t, "common.panic: {42}", err.Error())
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cause := errors.New("awful")
+		err := handlePanicOf(func() { panic(cause) })
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, PanicError))
+		require.Equal(t, cause, err.Cause())
+		require.Equal(t, "common.panic: awful", err.Error())
+		require.Contains(t, fmt.Sprintf("%+v", err), "handlePanicOf", err)
+	})
+
+	t.Run("Errorx", func(t *testing.T) {
+		err := handlePanicOf(func() { panic(funcWithErr()) })
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, PanicError))
+		require.True(t, IsOfType(err.Cause(), testType))
+		require.Equal(t, "common.panic: foo.bar: bad", err.Error())
+		require.Contains(t, fmt.Sprintf("%+v", err), "errorx.funcWithErr()", err)
+	})
+
+	t.Run("ErrorxPanic", func(t *testing.T) {
+		err := handlePanicOf(func() { Panic(funcWithErr()) })
+		require.NotNil(t, err)
+		require.True(t, IsOfType(err, PanicError))
+		require.True(t, IsOfType(err.Cause(), testType))
+		require.Contains(t, fmt.Sprintf("%+v", err), "errorx.funcWithErr()", err)
+	})
+}
+
+func handlePanicOf(f func()) (err *Error) {
+	defer func() {
+		err = HandlePanic(recover())
+	}()
+
+	f()
+	return nil
+}
+
+func TestTry(t *testing.T) {
+	t.Run("NoError", func(t *testing.T) {
+		require.NoError(t, Try(func() error { return nil }))
+	})
+
+	t.Run("Error", func(t *testing.T) {
+		cause := testType.New("bad")
+		require.Equal(t, cause, Try(func() error { return cause }))
+	})
+
+	t.Run("Panic", func(t *testing.T) {
+		err := Try(func() error {
+			panicInNestedFunc()
+			return nil
+		})
+		require.True(t, IsOfType(err, PanicError))
+		require.True(t, Cast(err).HasStackTrace())
+
+		_, _, function, ok := Cast(err).Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.panicInNestedFunc", function)
+	})
+
+	t.Run("ErrorPanic", func(t *testing.T) {
+		cause := testType.New("bad")
+		err := Try(func() error { panic(cause) })
+		require.True(t, IsOfType(err, PanicError))
+		require.Equal(t, cause, Cast(err).Cause())
+	})
+}
+
+func TestHandlePanicHooks(t *testing.T) {
+	defer SnapshotRegistry()()
+
+	// a hook observes an error in its final form, with a stack trace of the point of panic
+	var seen *Error
+	var origin string
+	PanicError.OnCreate(func(err *Error) {
+		seen = err
+		_, _, origin, _ = err.Origin()
+	}, false)
+
+	err := handlePanicOf(panicInNestedFunc)
+	require.True(t, seen == err)
+	require.Equal(t, "github.com/joomcode/errorx.panicInNestedFunc", origin)
+}
+
+func TestRecoveredAt(t *testing.T) {
+	t.Run("Value", func(t *testing.T) {
+		err := handlePanicOf(panicInNestedFunc)
+		require.NotNil(t, err)
+
+		recoveredAt := err.RecoveredAt()
+		require.NotEmpty(t, recoveredAt)
+		require.Equal(t, "github.com/joomcode/errorx.handlePanicOf.func1", recoveredAt[0].Function)
+		for _, frame := range recoveredAt {
+			require.NotEqual(t, "github.com/joomcode/errorx.panicInNestedFunc", frame.Function)
+		}
+
+		file, _, function, ok := err.Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.panicInNestedFunc", function)
+		require.NotEqual(t, recoveredAt[0].Function, function)
+		require.Contains(t, file, "panic_test.go")
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "recoveredAt", output)
+		require.NotContains(t, output, "handlePanicOf.func1", output)
+	})
+
+	t.Run("RuntimeError", func(t *testing.T) {
+		err := handlePanicOf(func() {
+			var m map[string]int
+			m["boom"]++
+		})
+		require.NotNil(t, err)
+
+		_, _, function, ok := err.Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.TestRecoveredAt.func2.1", function)
+	})
+
+	t.Run("Errorx", func(t *testing.T) {
+		err := handlePanicOf(func() { panic(funcWithErr()) })
+		require.NotEmpty(t, Decorate(err, "decorated").RecoveredAt())
+
+		_, _, function, ok := err.Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.funcWithErr", function)
+	})
+
+	t.Run("NotPanic", func(t *testing.T) {
+		require.Nil(t, testType.New("test").RecoveredAt())
+	})
+}
+
+func panicInNestedFunc() {
+	panic("boom")
+}
@@ -2,6 +2,7 @@ package errorx
 
 import (
 	"context"
+	"fmt"
 )
 
 // Property is a key to a dynamic property of an error.
@@ -14,19 +15,61 @@ type Property struct {
 type property struct {
 	label     string
 	printable bool
+	public    bool
+	stringer  func(interface{}) string
+	audiences []string
+	// internal is a property of errorx itself, which is never printed, visited, merged or compared
+	internal bool
 }
 
 // RegisterProperty registers a new property key.
 // It is used both to add a dynamic property to an error instance, and to extract property value back from error.
-func RegisterProperty(label string) Property {
-	return newProperty(label, false)
+// Options may restrict the visibility of a property, see VisibleTo.
+func RegisterProperty(label string, opts ...PropertyOption) Property {
+	return newProperty(label, false, false, opts...)
+}
+
+// RegisterPropertyWithStringer registers a new printable property key with a custom representation of its value.
+// The stringer is used instead of the default %v format wherever a value is printed or serialized: in an error message,
+// MarshalJSON output etc., see FormatValue(). For example, it may render a duration in a unit of choice.
+func RegisterPropertyWithStringer(label string, stringer func(interface{}) string) Property {
+	p := newProperty(label, true, false)
+	p.stringer = stringer
+	return p
 }
 
 // RegisterPrintableProperty registers a new property key for informational value.
 // It is used both to add a dynamic property to an error instance, and to extract property value back from error.
 // Printable property will be included in Error() message, both name and value.
-func RegisterPrintableProperty(label string) Property {
-	return newProperty(label, true)
+// Options may restrict the visibility of a property, see VisibleTo.
+func RegisterPrintableProperty(label string, opts ...PropertyOption) Property {
+	return newProperty(label, true, false, opts...)
+}
+
+// RegisterPublicProperty registers a new property key for informational value which is safe to be exposed to an outside observer.
+// It is used both to add a dynamic property to an error instance, and to extract property value back from error.
+// Public property is printable, and it is the only kind of property retained by Error.Public().
+func RegisterPublicProperty(label string) Property {
+	return newProperty(label, true, true)
+}
+
+// Label returns a label the property was registered with.
+func (p Property) Label() string {
+	return p.label
+}
+
+// IsPrintable checks if the property is printable, see RegisterPrintableProperty.
+func (p Property) IsPrintable() bool {
+	return p.printable
+}
+
+// FormatValue returns a string representation of a property value, as printed in an error message.
+// This is either a result of a stringer the property is registered with, see RegisterPropertyWithStringer, or the value in %v format.
+func (p Property) FormatValue(value interface{}) string {
+	if p.stringer != nil {
+		return p.stringer(value)
+	}
+	return fmt.Sprint(value)
 }
 
 // PropertyContext is a context property, value is expected to be of context.Context type.
@@ -66,10 +109,14 @@ func ExtractPayload(err error) (interface{}, bool) {
 
 // ExtractProperty attempts to extract a property value by a provided key.
 // A property may belong to this error or be extracted from the original cause.
+// For a non-errorx error, a property may be recognised by an adapter, see RegisterErrorAdapter.
 func ExtractProperty(err error, key Property) (interface{}, bool) {
 	typedErr := Cast(err)
 	if typedErr == nil {
-		return nil, false
+		if err == nil {
+			return nil, false
+		}
+		return adaptedProperty(err, key)
 	}
 
 	return typedErr.Property(key)
@@ -78,16 +125,28 @@ func ExtractProperty(err error, key Property) (interface{}, bool) {
 var (
 	propertyContext    = RegisterProperty("ctx")
 	propertyPayload    = RegisterProperty("payload")
-	propertyUnderlying = RegisterProperty("underlying")
+	propertyUnderlying = registerInternalProperty("underlying")
+	// propertyPublicMessage is a message to be used by Error.Public()
+	propertyPublicMessage = registerInternalProperty("publicMessage")
 )
 
-func newProperty(label string, printable bool) Property {
+func registerInternalProperty(label string) Property {
+	p := newProperty(label, false, false)
+	p.internal = true
+	return p
+}
+
+func newProperty(label string, printable bool, public bool, opts ...PropertyOption) Property {
 	p := Property{
 		&property{
 			label:     label,
 			printable: printable,
+			public:    public,
 		},
 	}
+	for _, opt := range opts {
+		opt(p.property)
+	}
 	return p
 }
 
@@ -1,8 +1,11 @@
 package errorx
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -201,3 +204,129 @@ func BenchmarkGetProperty(b *testing.B) {
 		})
 	}
 }
+
+func TestOwnProperty(t *testing.T) {
+	t.Run("Own", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 42)
+		property0, ok := err.OwnProperty(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 42, property0)
+	})
+
+	t.Run("FromCause", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 42)
+		err = Decorate(err, "oops")
+
+		property0, ok := err.Property(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 42, property0)
+
+		property0, ok = err.OwnProperty(testProperty0)
+		require.False(t, ok)
+		require.Nil(t, property0)
+	})
+
+	t.Run("OverrideCause", func(t *testing.T) {
+		err := testType.New("test").WithProperty(testProperty0, 42)
+		err = Decorate(err, "oops").WithProperty(testProperty0, 43)
+
+		property0, ok := err.OwnProperty(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 43, property0)
+
+		property0, ok = err.Cause().(*Error).OwnProperty(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 42, property0)
+	})
+}
+
+func TestVisitProperties(t *testing.T) {
+	err := testType.New("test").WithProperty(testProperty0, 42).WithProperty(testInfoProperty2, "a")
+	err = Decorate(err, "oops").WithProperty(testInfoProperty2, "b").WithUnderlyingErrors(testType.New("hidden"))
+	err = Decorate(err, "bad").WithProperty(testInfoProperty3, "c")
+
+	visited := map[string]interface{}{}
+	err.VisitProperties(func(key Property, value interface{}) {
+		_, ok := visited[key.Label()]
+		require.False(t, ok, key.Label())
+		visited[key.Label()] = value
+	})
+
+	require.Equal(t, map[string]interface{}{"test0": 42, "prop2": "b", "prop3": "c"}, visited)
+	require.True(t, testInfoProperty2.IsPrintable())
+	require.False(t, testProperty0.IsPrintable())
+}
+
+func TestMergePropertiesFrom(t *testing.T) {
+	src := Decorate(testType.New("test").WithProperty(testProperty0, 1), "decorated").
+		WithProperty(testInfoProperty2, 2).
+		WithProperty(testInfoProperty3, 3).
+		WithUnderlyingErrors(testTypeBar1.New("hidden"))
+
+	t.Run("OwnPropertiesWin", func(t *testing.T) {
+		err := testTypeBar2.New("retyped").WithProperty(testInfoProperty3, 30).MergePropertiesFrom(src)
+
+		property0, ok := err.Property(testProperty0)
+		require.True(t, ok)
+		require.EqualValues(t, 1, property0)
+
+		property2, ok := err.Property(testInfoProperty2)
+		require.True(t, ok)
+		require.EqualValues(t, 2, property2)
+
+		property3, ok := err.Property(testInfoProperty3)
+		require.True(t, ok)
+		require.EqualValues(t, 30, property3)
+
+		require.Equal(t, "foo.bar2: retyped {prop2: 2, prop3: 30}", err.Error())
+	})
+
+	t.Run("TransparentWrapperWins", func(t *testing.T) {
+		err := Decorate(testTypeBar2.New("retyped").WithProperty(testInfoProperty2, 20), "decorated").MergePropertiesFrom(src)
+
+		property2, ok := err.Property(testInfoProperty2)
+		require.True(t, ok)
+		require.EqualValues(t, 20, property2)
+	})
+
+	t.Run("OriginalUnchanged", func(t *testing.T) {
+		original := testTypeBar2.New("retyped")
+		_ = original.MergePropertiesFrom(src)
+
+		_, ok := original.Property(testInfoProperty2)
+		require.False(t, ok)
+	})
+
+	t.Run("NonErrorx", func(t *testing.T) {
+		err := testTypeBar2.New("retyped")
+		require.Equal(t, err, err.MergePropertiesFrom(errors.New("test")))
+		require.Equal(t, err, err.MergePropertiesFrom(nil))
+	})
+}
+
+func TestPropertyWithStringer(t *testing.T) {
+	latency := RegisterPropertyWithStringer("latency", func(value interface{}) string {
+		return fmt.Sprintf("%.1fs", value.(time.Duration).Seconds())
+	})
+
+	err := testType.New("test").WithProperty(latency, 1500*time.Millisecond)
+
+	t.Run("Format", func(t *testing.T) {
+		require.Equal(t, "foo.bar: test {latency: 1.5s}", err.Error())
+		require.Equal(t, "foo.bar: test {latency: 1.5s}", firstLine(fmt.Sprintf("%+v", err)))
+		require.Equal(t, "1.5s", latency.FormatValue(1500*time.Millisecond))
+		require.Equal(t, "1.5s", testInfoProperty2.FormatValue(1500*time.Millisecond))
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, jsonErr := json.Marshal(err)
+		require.NoError(t, jsonErr)
+		require.JSONEq(t, `{"type": "foo.bar", "message": "test", "properties": {"latency": "1.5s"}}`, string(data))
+	})
+
+	t.Run("Value", func(t *testing.T) {
+		value, ok := err.Property(latency)
+		require.True(t, ok)
+		require.Equal(t, 1500*time.Millisecond, value)
+	})
+}
@@ -0,0 +1,86 @@
+package errorx
+
+// Public returns a sanitized copy of an error, which is safe to be exposed to an outside observer, say, an API client.
+// The copy retains the type of an error, which is the same as Type() would return,
+// and a public message provided with ErrorBuilder.WithPublicMessage(), if there is one.
+// Otherwise, the copy has no message, so the type name alone describes it.
+// Of all the properties, only those registered with RegisterPublicProperty() are retained.
+// Cause, underlying errors and stack trace are all dropped.
+//
+// Decorated non-errorx errors are considered to be InternalError, as nothing is known about them.
+func (e *Error) Public() *Error {
+	errorType := e.Type()
+	if errorType == foreignType {
+		errorType = InternalError
+	}
+
+	public := &Error{
+		errorType: errorType,
+		sanitized: true,
+	}
+
+	if message, ok := e.Property(propertyPublicMessage); ok {
+		public.message = message.(string)
+		public = public.WithProperty(propertyPublicMessage, message)
+	}
+
+	properties := e.visibleProperties(func(p Property) bool { return p.public })
+	for i := len(properties) - 1; i >= 0; i-- {
+		public = public.WithProperty(properties[i].p, properties[i].value)
+	}
+
+	return public
+}
+
+// visibleProperties collects the values of properties which are visible from this error, as with Property(), in order of precedence.
+func (e *Error) visibleProperties(filter func(Property) bool) []*propertyMap {
+	var result []*propertyMap
+	seen := make(map[Property]struct{})
+
+	cause := e
+	for cause != nil {
+		for m := cause.properties; m != nil; m = m.next {
+			if _, ok := seen[m.p]; ok {
+				continue
+			}
+
+			seen[m.p] = struct{}{}
+			if filter(m.p) {
+				result = append(result, m)
+			}
+		}
+
+		if !cause.transparent {
+			break
+		}
+
+		cause = Cast(cause.Cause())
+	}
+
+	return result
+}
+
+// AssertPublic checks that an error is safe to be exposed to an outside observer, which is meant to guard an API boundary, say, in tests.
+// An error is considered public if it is a result of Error.Public(), or if it has a public message, see ErrorBuilder.WithPublicMessage().
+// Returns nil for a nil error, the error itself if it is public, and AssertionFailed error with the original one as a cause otherwise.
+func AssertPublic(err error) error {
+	if err == nil || isPublic(err) {
+		return err
+	}
+
+	return AssertionFailed.Wrap(err, "error is not public")
+}
+
+func isPublic(err error) bool {
+	typedErr := Cast(err)
+	if typedErr == nil {
+		return false
+	}
+
+	if typedErr.sanitized {
+		return true
+	}
+
+	_, ok := typedErr.Property(propertyPublicMessage)
+	return ok
+}
@@ -0,0 +1,120 @@
+package errorx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testPublicProperty = RegisterPublicProperty("field")
+	testSecretProperty = RegisterPrintableProperty("password")
+)
+
+func TestPublic(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		err := IllegalArgument.New("user secret@example.com is banned")
+		public := err.Public()
+		require.True(t, IsOfType(public, IllegalArgument))
+		require.Equal(t, "common.illegal_argument", public.Error())
+		require.Equal(t, "common.illegal_argument", fmt.Sprintf("%+v", public))
+	})
+
+	t.Run("PublicMessage", func(t *testing.T) {
+		err := NewErrorBuilder(IllegalArgument).
+			WithConditionallyFormattedMessage("user secret@example.com is banned").
+			WithPublicMessage("user is banned").
+			Create()
+		public := err.Public()
+		require.Equal(t, "common.illegal_argument: user is banned", public.Error())
+		require.Equal(t, "user is banned", public.Message())
+		require.Equal(t, public.Error(), public.Public().Error())
+	})
+
+	t.Run("NothingLeaks", func(t *testing.T) {
+		cause := errors.New("dial tcp 10.0.0.1:5432: connection refused")
+		err := NewErrorBuilder(testType).
+			WithConditionallyFormattedMessage("query failed").
+			WithCause(cause).
+			WithPublicMessage("service unavailable").
+			Create().
+			WithProperty(testPublicProperty, "login").
+			WithProperty(testSecretProperty, "hunter2").
+			WithUnderlyingErrors(testTypeBar1.New("hidden"))
+		err = Decorate(err, "handler")
+
+		public := err.Public()
+		require.Equal(t, "foo.bar: service unavailable {field: login}", public.Error())
+
+		output := fmt.Sprintf("%+v", public)
+		require.Equal(t, public.Error(), output)
+		require.NotContains(t, output, "10.0.0.1")
+		require.NotContains(t, output, "hunter2")
+		require.NotContains(t, output, "hidden")
+		require.NotContains(t, output, "handler")
+		require.Nil(t, public.Cause())
+
+		value, ok := public.Property(testPublicProperty)
+		require.True(t, ok)
+		require.Equal(t, "login", value)
+
+		_, ok = public.Property(testSecretProperty)
+		require.False(t, ok)
+	})
+
+	t.Run("OpaqueWrap", func(t *testing.T) {
+		cause := NewErrorBuilder(testType).
+			WithPublicMessage("inner public message").
+			Create().
+			WithProperty(testPublicProperty, "inner")
+		public := testTypeBar1.Wrap(cause, "wrapped").Public()
+		require.Equal(t, "foo.bar1", public.Error())
+	})
+
+	t.Run("NonErrorx", func(t *testing.T) {
+		public := Decorate(errors.New("secret"), "decorated").Public()
+		require.True(t, IsOfType(public, InternalError))
+		require.Equal(t, "common.internal_error", public.Error())
+	})
+}
+
+func TestAssertPublic(t *testing.T) {
+	t.Run("Internal", func(t *testing.T) {
+		internal := InternalError.Wrap(errors.New("connection refused"), "database is down")
+
+		err := AssertPublic(internal)
+		require.Error(t, err)
+		require.True(t, IsOfType(err, AssertionFailed))
+		require.Equal(t, internal, Cast(err).Cause())
+
+		require.Error(t, AssertPublic(errors.New("test")))
+	})
+
+	t.Run("Public", func(t *testing.T) {
+		internal := InternalError.Wrap(errors.New("connection refused"), "database is down")
+		public := internal.Public()
+		require.Equal(t, public, AssertPublic(public))
+
+		withMessage := NewErrorBuilder(IllegalArgument).WithPublicMessage("bad request").Create()
+		require.Equal(t, withMessage, AssertPublic(withMessage))
+
+		decorated := Decorate(withMessage, "decorated")
+		require.Equal(t, decorated, AssertPublic(decorated))
+	})
+
+	t.Run("Merged", func(t *testing.T) {
+		secret := errors.New("db password=hunter2")
+		public := NewErrorBuilder(IllegalArgument).WithPublicMessage("bad request").Create().Public()
+		require.Equal(t, public, AssertPublic(public))
+
+		merged := InternalError.Wrap(secret, "x").MergePropertiesFrom(public)
+		require.Error(t, AssertPublic(merged))
+		require.Contains(t, merged.Error(), "hunter2")
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		require.NoError(t, AssertPublic(nil))
+	})
+}
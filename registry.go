@@ -19,11 +19,43 @@ func RegisterTypeSubscriber(s TypeSubscriber) {
 	globalRegistry.registerTypeSubscriber(s)
 }
 
+// SnapshotRegistry captures the current state of global registration and returns a function to restore it.
+// It is designed for tests that register ephemeral namespaces, types or other global settings.
+// Captured are namespaces, types and their aliases, type subscribers, hooks of types, see Type.OnCreate(),
+// stack trace transformer, error adapters, and the settings of an output, such as SetCauseSeparator() or SetStackTraceStyle():
+//
+//	defer errorx.SnapshotRegistry()()
+//
+// Note that errors, types etc. created in between remain valid, they are only forgotten by the registry.
+// Restoration is not meant to be performed concurrently with any registration.
+func SnapshotRegistry() func() {
+	restoreRegistry := globalRegistry.snapshot()
+	restoreTransformer := stackTraceTransformer.snapshot()
+	restoreAdapters := snapshotErrorAdapters()
+	restoreFormat := snapshotFormatSettings()
+
+	return func() {
+		restoreFormat()
+		restoreAdapters()
+		restoreTransformer()
+		restoreRegistry()
+	}
+}
+
+// TypeByName looks up a registered type by its full name or by one of its aliases, see Type.RegisterAlias().
+// This may be used to reconstruct an error from its serialized form.
+// If several types share the same full name, the one registered first is returned.
+func TypeByName(fullName string) (*Type, bool) {
+	return globalRegistry.typeByName(fullName)
+}
+
 type registry struct {
 	mu              sync.Mutex
 	subscribers     []TypeSubscriber
 	knownNamespaces []Namespace
 	knownTypes      []*Type
+	typesByName     map[string]*Type
+	typeAliases     map[string]*Type
 }
 
 var globalRegistry = &registry{}
@@ -43,12 +75,59 @@ func (r *registry) registerType(t *Type) {
 	defer r.mu.Unlock()
 
 	r.knownTypes = append(r.knownTypes, t)
+	if _, ok := r.typesByName[t.FullName()]; !ok {
+		if r.typesByName == nil {
+			r.typesByName = make(map[string]*Type)
+		}
+		r.typesByName[t.FullName()] = t
+	}
+
 	for _, s := range r.subscribers {
 		s.OnTypeCreated(t)
 	}
 }
 
+func (r *registry) registerTypeAlias(t *Type, alias string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if other, ok := r.typesByName[alias]; ok {
+		if other == t {
+			return nil
+		}
+		return InitializationFailed.New("alias %s of type %s is a name of another registered type %s", alias, t.FullName(), other.FullName())
+	}
+
+	if other, ok := r.typeAliases[alias]; ok {
+		if other == t {
+			return nil
+		}
+		return InitializationFailed.New("alias %s of type %s is already registered for type %s", alias, t.FullName(), other.FullName())
+	}
+
+	if r.typeAliases == nil {
+		r.typeAliases = make(map[string]*Type)
+	}
+	r.typeAliases[alias] = t
+	return nil
+}
+
+func (r *registry) typeByName(fullName string) (*Type, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if t, ok := r.typesByName[fullName]; ok {
+		return t, true
+	}
+
+	t, ok := r.typeAliases[fullName]
+	return t, ok
+}
+
 func (r *registry) registerTypeSubscriber(s TypeSubscriber) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
 	for _, ns := range r.knownNamespaces {
 		s.OnNamespaceCreated(ns)
 	}
@@ -59,3 +138,36 @@ func (r *registry) registerTypeSubscriber(s TypeSubscriber) {
 
 	r.subscribers = append(r.subscribers, s)
 }
+
+func (r *registry) snapshot() func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subscribers := append([]TypeSubscriber(nil), r.subscribers...)
+	knownNamespaces := append([]Namespace(nil), r.knownNamespaces...)
+	knownTypes := append([]*Type(nil), r.knownTypes...)
+	typesByName := copyTypeMap(r.typesByName)
+	typeAliases := copyTypeMap(r.typeAliases)
+	restoreHooks := snapshotCreateHooks(knownTypes)
+
+	return func() {
+		restoreHooks()
+
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.subscribers = subscribers
+		r.knownNamespaces = knownNamespaces
+		r.knownTypes = knownTypes
+		r.typesByName = typesByName
+		r.typeAliases = typeAliases
+	}
+}
+
+func copyTypeMap(m map[string]*Type) map[string]*Type {
+	result := make(map[string]*Type, len(m))
+	for k, v := range m {
+		result[k] = v
+	}
+	return result
+}
@@ -1,6 +1,8 @@
 package errorx
 
 import (
+	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -32,3 +34,146 @@ func (s *testSubscriber) OnNamespaceCreated(namespace Namespace) {
 func (s *testSubscriber) OnTypeCreated(t *Type) {
 	s.types = append(s.types, t)
 }
+
+func TestSnapshotRegistry(t *testing.T) {
+	defer SnapshotRegistry()()
+
+	t.Run("Register", func(t *testing.T) {
+		defer SnapshotRegistry()()
+
+		s := &testSubscriber{}
+		RegisterTypeSubscriber(s)
+
+		ns := NewNamespace("TestSnapshotRegistry")
+		errorType := ns.NewType("Ephemeral")
+		require.Contains(t, s.namespaces, ns.Key())
+		require.Contains(t, s.types, errorType)
+
+		_, err := InitializeStackTraceTransformer(func(s string) string { return s })
+		require.NoError(t, err)
+	})
+
+	t.Run("Isolated", func(t *testing.T) {
+		defer SnapshotRegistry()()
+
+		s := &testSubscriber{}
+		RegisterTypeSubscriber(s)
+
+		require.Contains(t, s.types, AssertionFailed)
+		for _, errorType := range s.types {
+			require.NotEqual(t, "TestSnapshotRegistry.Ephemeral", errorType.FullName())
+		}
+
+		_, err := InitializeStackTraceTransformer(func(s string) string { return s })
+		require.NoError(t, err)
+	})
+
+	t.Run("Hooks", func(t *testing.T) {
+		var calls int
+		restore := SnapshotRegistry()
+		testTypeBar1.OnCreate(func(*Error) { calls++ }, false)
+		_ = testTypeBar1.New("test")
+		restore()
+
+		_ = testTypeBar1.New("test")
+		require.Equal(t, 1, calls)
+	})
+
+	t.Run("FormatSettings", func(t *testing.T) {
+		err := testType.Wrap(errors.New("cause"), "test")
+		restore := SnapshotRegistry()
+		SetCauseSeparator(" <- ")
+		SetShowAllProperties(true)
+		SetStackTraceStyle(StackTraceStyleGoPanic)
+		require.Equal(t, "foo.bar: test <- cause", err.Error())
+		restore()
+
+		require.Equal(t, "foo.bar: test, cause: cause", err.Error())
+		require.False(t, showAllProperties())
+		require.False(t, isGoPanicStyle())
+	})
+
+	t.Run("Subscribers", func(t *testing.T) {
+		s := &testSubscriber{}
+		restore := SnapshotRegistry()
+		RegisterTypeSubscriber(s)
+		restore()
+
+		NewNamespace("TestSnapshotRegistryAfterRestore")
+		require.NotContains(t, s.namespaces, globalRegistry.knownNamespaces[len(globalRegistry.knownNamespaces)-1].Key())
+	})
+}
+
+func TestTypeByName(t *testing.T) {
+	t.Run("Known", func(t *testing.T) {
+		found, ok := TypeByName("common.illegal_argument")
+		require.True(t, ok)
+		require.Equal(t, IllegalArgument, found)
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		_, ok := TypeByName("TestTypeByName.no_such_type")
+		require.False(t, ok)
+	})
+}
+
+func TestRegisterAlias(t *testing.T) {
+	defer SnapshotRegistry()()
+
+	ns := NewNamespace("TestRegisterAlias")
+	renamed := ns.NewType("renamed")
+	other := ns.NewType("other")
+
+	t.Run("Deserialize", func(t *testing.T) {
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.original"))
+
+		var serialized struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(`{"type": "TestRegisterAlias.original", "message": "test"}`), &serialized))
+
+		errorType, ok := TypeByName(serialized.Type)
+		require.True(t, ok)
+		require.Equal(t, renamed, errorType)
+
+		err := errorType.New(serialized.Message)
+		require.True(t, IsOfType(err, renamed))
+		require.Equal(t, "TestRegisterAlias.renamed: test", err.Error())
+
+		current, ok := TypeByName("TestRegisterAlias.renamed")
+		require.True(t, ok)
+		require.Equal(t, renamed, current)
+	})
+
+	t.Run("Repeated", func(t *testing.T) {
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.repeated"))
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.repeated"))
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.renamed"))
+	})
+
+	t.Run("Collision", func(t *testing.T) {
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.taken"))
+
+		err := other.RegisterAlias("TestRegisterAlias.taken")
+		require.Error(t, err)
+		require.True(t, IsOfType(err, InitializationFailed))
+
+		err = other.RegisterAlias("TestRegisterAlias.renamed")
+		require.Error(t, err)
+		require.True(t, IsOfType(err, InitializationFailed))
+
+		aliased, ok := TypeByName("TestRegisterAlias.taken")
+		require.True(t, ok)
+		require.Equal(t, renamed, aliased)
+	})
+
+	t.Run("Restored", func(t *testing.T) {
+		restore := SnapshotRegistry()
+		require.NoError(t, renamed.RegisterAlias("TestRegisterAlias.ephemeral"))
+		restore()
+
+		_, ok := TypeByName("TestRegisterAlias.ephemeral")
+		require.False(t, ok)
+	})
+}
@@ -0,0 +1,64 @@
+package errorx
+
+import (
+	"bufio"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// SetIncludeSourceLines enables printing of a source code line beneath each frame of a stack trace in a full (%+v) output.
+// Source file is read from the original location at the moment of formatting. Frames whose source is not accessible,
+// which is a typical case for a production deployment, are printed as usual, without a source line.
+// This is intended for local development and tests, and is disabled by default.
+func SetIncludeSourceLines(include bool) {
+	value := int32(0)
+	if include {
+		value = 1
+	}
+	atomic.StoreInt32(&sourceLinesEnabled, value)
+}
+
+var sourceLinesEnabled int32
+
+func includeSourceLines() bool {
+	return atomic.LoadInt32(&sourceLinesEnabled) != 0
+}
+
+// sourceFiles caches lines of files read for stack trace output, a file which failed to be read is cached as nil
+var sourceFiles sync.Map
+
+func sourceLine(file string, line int) (string, bool) {
+	cached, ok := sourceFiles.Load(file)
+	if !ok {
+		cached, _ = sourceFiles.LoadOrStore(file, readSourceLines(file))
+	}
+
+	lines := cached.([]string)
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+
+	text := strings.TrimSpace(lines[line-1])
+	return text, text != ""
+}
+
+func readSourceLines(file string) []string {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+
+	if scanner.Err() != nil {
+		return nil
+	}
+	return lines
+}
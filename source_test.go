@@ -0,0 +1,31 @@
+package errorx
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncludeSourceLines(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		output := fmt.Sprintf("%+v", createErrorFuncInStackTrace(testType))
+		require.NotContains(t, output, "err := et.NewWithNoMessage()", output)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		SetIncludeSourceLines(true)
+		defer SetIncludeSourceLines(false)
+
+		output := fmt.Sprintf("%+v", createErrorFuncInStackTrace(testType))
+		require.Regexp(t, `error_test.go:\d+\n\t\terr := et.NewWithNoMessage\(\)\n at `, output)
+	})
+
+	t.Run("Unavailable", func(t *testing.T) {
+		_, ok := sourceLine("/no/such/dir/file.go", 1)
+		require.False(t, ok)
+
+		_, ok = sourceLine("source_test.go", 100500)
+		require.False(t, ok)
+	})
+}
@@ -8,6 +8,8 @@ type frame interface {
 	Function() string
 	File() string
 	Line() int
+	// Offset is a distance of a program counter from the function entry, or zero if unknown
+	Offset() uintptr
 }
 
 type frameHelper struct {
@@ -31,18 +33,21 @@ func (f *defaultFrame) Line() int {
 	return f.frame.Line
 }
 
+func (f *defaultFrame) Offset() uintptr {
+	// an inlined frame has no function of its own
+	if f.frame.Func == nil || f.frame.PC < f.frame.Entry {
+		return 0
+	}
+	return f.frame.PC - f.frame.Entry
+}
+
 func (c *frameHelper) GetFrames(pcs []uintptr) []frame {
-	frames := runtime.CallersFrames(pcs[:])
 	result := make([]frame, 0, len(pcs))
-
-	var rawFrame runtime.Frame
-	next := true
-	for next {
-		rawFrame, next = frames.Next()
+	visitFrames(pcs, func(rawFrame runtime.Frame) bool {
 		frameCopy := rawFrame
-		frame := &defaultFrame{&frameCopy}
-		result = append(result, frame)
-	}
+		result = append(result, &defaultFrame{&frameCopy})
+		return true
+	})
 
 	return result
 }
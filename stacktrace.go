@@ -1,10 +1,13 @@
 package errorx
 
 import (
-	"fmt"
 	"io"
+	"math"
+	"math/rand"
+	"reflect"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"sync/atomic"
 )
@@ -35,18 +38,103 @@ func InitializeStackTraceTransformer(transformer StackTraceFilePathTransformer)
 	return nil, nil
 }
 
-var stackTraceTransformer = struct {
+type transformerHolder struct {
 	mu          *sync.Mutex
 	transform   *atomic.Value
 	initialized bool
-}{
+}
+
+var stackTraceTransformer = &transformerHolder{
 	&sync.Mutex{},
 	&atomic.Value{},
 	false,
 }
 
+func (h *transformerHolder) snapshot() func() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	transform := h.transform.Load().(StackTraceFilePathTransformer)
+	initialized := h.initialized
+
+	return func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		h.transform.Store(transform)
+		h.initialized = initialized
+	}
+}
+
+// SetSymbolizationConcurrency limits a number of goroutines which may simultaneously resolve stack traces into frames.
+// Resolution happens once per stack trace, upon its first formatting, and contends on a runtime lock.
+// Under a burst of errors formatted all at once, a bounded concurrency reduces this contention and thus the tail latency.
+// Zero or negative limit restores the default, which is GOMAXPROCS at the moment of the call.
+func SetSymbolizationConcurrency(n int) {
+	if n <= 0 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	symbolizationSemaphore.Store(make(chan struct{}, n))
+}
+
+// StackTraceStyle defines how a stack trace is rendered in a full (%+v) output of an error, see SetStackTraceStyle.
+type StackTraceStyle int32
+
+const (
+	// StackTraceStyleErrorx renders each frame as " at function()" followed by a file and a line; this is the default
+	StackTraceStyleErrorx StackTraceStyle = 0
+	// StackTraceStyleGoPanic renders a stack trace as Go runtime does upon a panic, so that the tools which parse it may be used:
+	//
+	//	goroutine 0 [running]:
+	//	main.load(...)
+	//		/src/main.go:10 +0x1d
+	//
+	// An error does not record a goroutine it was created in, so its number is always zero.
+	// Stack traces of the causes, see EnhanceStackTrace(), are rendered as separate goroutines.
+	StackTraceStyleGoPanic StackTraceStyle = 1
+)
+
+// SetStackTraceStyle changes how a stack trace is rendered in a full (%+v) output of an error. Stack traces are collected the same either way.
+// With StackTraceStyleGoPanic, source lines are never included, see SetIncludeSourceLines().
+func SetStackTraceStyle(style StackTraceStyle) {
+	atomic.StoreInt32(&stackTraceStyle, int32(style))
+}
+
+var stackTraceStyle int32
+
+func isGoPanicStyle() bool {
+	return StackTraceStyle(atomic.LoadInt32(&stackTraceStyle)) == StackTraceStyleGoPanic
+}
+
+// SetStackTraceSampling makes only a fraction of created errors collect a stack trace, chosen at random with a given rate.
+// An error which is not sampled has no stack trace, as with ErrorBuilder.WithoutStackTrace(), and a stack trace enhancement
+// for such an error has no effect, so that it retains the stack trace of its cause.
+// This reduces an overhead of error creation, which is dominated by a stack trace collection, on an extremely hot path,
+// yet leaves some stack traces for debugging. The price is that most errors, including the one which matters, may lack a trace.
+// Rate is clamped to [0, 1], and the default is 1, that is, all errors collect a stack trace.
+func SetStackTraceSampling(rate float64) {
+	if rate < 0 {
+		rate = 0
+	} else if rate > 1 {
+		rate = 1
+	}
+	atomic.StoreUint64(&stackTraceSamplingRate, math.Float64bits(rate))
+}
+
+var stackTraceSamplingRate = math.Float64bits(1)
+
+func isStackTraceSampled() bool {
+	rate := math.Float64frombits(atomic.LoadUint64(&stackTraceSamplingRate))
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// symbolizationSemaphore holds a buffered channel, with a slot taken for each ongoing resolution of frames.
+// On a change of limit, the channel is replaced, and resolutions already in progress release the slot of the old one.
+var symbolizationSemaphore atomic.Value
+
 func init() {
 	stackTraceTransformer.transform.Store(transformStackTraceLineNoop)
+	SetSymbolizationConcurrency(0)
 }
 
 var transformStackTraceLineNoop StackTraceFilePathTransformer = func(line string) string {
@@ -60,61 +148,250 @@ const (
 	skippedFrames = 6
 )
 
+// collectStackTrace only captures program counters, which is cheap compared to resolving them into frames.
+// Frames are resolved lazily upon the first formatting, see stackTrace.resolveFrames.
 func collectStackTrace() *stackTrace {
 	var pc [stackTraceDepth]uintptr
 	depth := runtime.Callers(skippedFrames, pc[:])
 	return &stackTrace{
-		pc: pc[:depth],
+		pc: append(make([]uintptr, 0, depth), pc[:depth]...),
 	}
 }
 
 type stackTrace struct {
 	pc              []uintptr
 	causeStackTrace *stackTrace
+	// keepFirstFrame retains the first frame even if it is duplicated in the cause stack trace, see Checkpoint
+	keepFirstFrame bool
+
+	// frames are resolved once, as stack trace is immutable after the error is created
+	resolveOnce sync.Once
+	frames      []frame
+	cropped     int
+
+	// all frames, including those duplicated in the cause stack trace, are only resolved if requested, see writeOuterTo
+	resolveAllOnce sync.Once
+	allFrames      []frame
+}
+
+// clone copies program counters, but neither the cause stack trace, which is shared, nor the resolved frames
+func (st *stackTrace) clone() *stackTrace {
+	if st == nil {
+		return nil
+	}
+
+	return &stackTrace{
+		pc:              append([]uintptr(nil), st.pc...),
+		causeStackTrace: st.causeStackTrace,
+		keepFirstFrame:  st.keepFirstFrame,
+	}
 }
 
 func (st *stackTrace) enhanceWithCause(causeStackTrace *stackTrace) {
 	st.causeStackTrace = causeStackTrace
 }
 
-func (st *stackTrace) Format(s fmt.State, verb rune) {
+func (st *stackTrace) writeTo(w io.Writer) {
 	if st == nil {
 		return
 	}
 
-	switch verb {
-	case 'v', 's':
-		st.formatStackTrace(s)
+	st.formatStackTrace(w)
 
-		if st.causeStackTrace != nil {
-			io.WriteString(s, "\n ---------------------------------- ")
-			st.causeStackTrace.Format(s, verb)
+	if st.causeStackTrace != nil {
+		if !isGoPanicStyle() {
+			io.WriteString(w, "\n ---------------------------------- ")
 		}
+		st.causeStackTrace.writeTo(w)
 	}
 }
 
-func (st *stackTrace) formatStackTrace(s fmt.State) {
+// origin finds the first frame outside of both runtime and errorx
+func (st *stackTrace) origin() (runtime.Frame, bool) {
+	var result runtime.Frame
+	found := false
+	visitFrames(st.pc, func(frame runtime.Frame) bool {
+		if frame.Function != "" && !isInternalFrame(frame) {
+			result, found = frame, true
+		}
+		return !found
+	})
+
+	return result, found
+}
+
+// CompareStacks finds how many frames the stack traces of two errors have in common, counting from the root, that is, from the outermost call.
+// Frames are matched by file and line, so that the same call site matches regardless of the exact program counter.
+// This may be used to cluster the errors which share an origin, yet diverge in the way they fail.
+// For decorated errors, the stack trace of an original cause is used. If either error has no stack trace, the result is not ok.
+func CompareStacks(a, b error) (commonPrefixLen int, ok bool) {
+	traceA, traceB := extractStackTrace(a), extractStackTrace(b)
+	if traceA == nil || traceB == nil {
+		return 0, false
+	}
+
+	locationsA, locationsB := traceA.locations(), traceB.locations()
+	for commonPrefixLen < len(locationsA) && commonPrefixLen < len(locationsB) {
+		if locationsA[len(locationsA)-1-commonPrefixLen] != locationsB[len(locationsB)-1-commonPrefixLen] {
+			break
+		}
+		commonPrefixLen++
+	}
+
+	return commonPrefixLen, true
+}
+
+func extractStackTrace(err error) *stackTrace {
+	typedErr := Cast(err)
+	if typedErr == nil {
+		return nil
+	}
+
+	return typedErr.stackTrace
+}
+
+// locations lists file:line of each frame, innermost first, as recorded with no regard for the cause stack trace
+func (st *stackTrace) locations() []string {
+	var result []string
+	visitFrames(st.pc, func(frame runtime.Frame) bool {
+		result = append(result, frame.File+":"+strconv.Itoa(frame.Line))
+		return true
+	})
+
+	return result
+}
+
+var errorxPackagePrefix = reflect.TypeOf(Error{}).PkgPath() + "."
+
+func isInternalFrame(frame runtime.Frame) bool {
+	if strings.HasPrefix(frame.Function, "runtime.") {
+		return true
+	}
+
+	// tests of errorx itself are as good an origin as any other code
+	return strings.HasPrefix(frame.Function, errorxPackagePrefix) && !strings.HasSuffix(frame.File, "_test.go")
+}
+
+// writeOuterTo writes this stack trace alone, in its entirety, regardless of the cause stack trace
+func (st *stackTrace) writeOuterTo(w io.Writer) {
+	if st == nil {
+		return
+	}
+
+	if st.causeStackTrace == nil {
+		st.formatStackTrace(w)
+		return
+	}
+
+	st.resolveAllOnce.Do(func() {
+		st.allFrames = symbolize(st.pc)
+	})
+	writeFrames(w, st.allFrames, 0)
+}
+
+func (st *stackTrace) formatStackTrace(w io.Writer) {
+	frames, cropped := st.resolveFrames()
+	writeFrames(w, frames, cropped)
+}
+
+func writeFrames(w io.Writer, frames []frame, cropped int) {
+	if isGoPanicStyle() {
+		writeGoPanicFrames(w, frames, cropped)
+		return
+	}
+
 	transformLine := stackTraceTransformer.transform.Load().(StackTraceFilePathTransformer)
 
-	pc, cropped := st.deduplicateFramesWithCause()
-	if len(pc) == 0 {
+	withSource := includeSourceLines()
+
+	for _, frame := range frames {
+		io.WriteString(w, "\n at ")
+		io.WriteString(w, frame.Function())
+		io.WriteString(w, "()\n\t")
+		io.WriteString(w, transformLine(frame.File()))
+		io.WriteString(w, ":")
+		io.WriteString(w, strconv.Itoa(frame.Line()))
+
+		if withSource {
+			if text, ok := sourceLine(frame.File(), frame.Line()); ok {
+				io.WriteString(w, "\n\t\t")
+				io.WriteString(w, text)
+			}
+		}
+	}
+
+	if cropped > 0 {
+		io.WriteString(w, "\n ...\n (")
+		io.WriteString(w, strconv.Itoa(cropped))
+		io.WriteString(w, " duplicated frames)")
+	}
+}
+
+func writeGoPanicFrames(w io.Writer, frames []frame, cropped int) {
+	// a block of elided frames alone would claim to be cut from the bottom, while all of them are in the cause stack trace
+	if len(frames) == 0 {
 		return
 	}
 
-	frames := frameHelperSingleton.GetFrames(pc)
+	transformLine := stackTraceTransformer.transform.Load().(StackTraceFilePathTransformer)
+
+	io.WriteString(w, "\n\ngoroutine 0 [running]:")
 	for _, frame := range frames {
-		io.WriteString(s, "\n at ")
-		io.WriteString(s, frame.Function())
-		io.WriteString(s, "()\n\t")
-		io.WriteString(s, transformLine(frame.File()))
-		io.WriteString(s, ":")
-		io.WriteString(s, strconv.Itoa(frame.Line()))
+		io.WriteString(w, "\n")
+		io.WriteString(w, frame.Function())
+		io.WriteString(w, "(...)\n\t")
+		io.WriteString(w, transformLine(frame.File()))
+		io.WriteString(w, ":")
+		io.WriteString(w, strconv.Itoa(frame.Line()))
+
+		if offset := frame.Offset(); offset > 0 {
+			io.WriteString(w, " +0x")
+			io.WriteString(w, strconv.FormatUint(uint64(offset), 16))
+		}
 	}
 
 	if cropped > 0 {
-		io.WriteString(s, "\n ...\n (")
-		io.WriteString(s, strconv.Itoa(cropped))
-		io.WriteString(s, " duplicated frames)")
+		io.WriteString(w, "\n...additional frames elided...")
+	}
+}
+
+// resolveFrames returns the frames of this stack trace, except for those duplicated in the cause stack trace, and a count of the latter.
+// It is safe for concurrent use.
+func (st *stackTrace) resolveFrames() ([]frame, int) {
+	st.resolveOnce.Do(func() {
+		pc, cropped := st.deduplicateFramesWithCause()
+		st.frames = symbolize(pc)
+		st.cropped = cropped
+	})
+
+	return st.frames, st.cropped
+}
+
+func symbolize(pc []uintptr) []frame {
+	if len(pc) == 0 {
+		return nil
+	}
+
+	return frameHelperSingleton.GetFrames(pc)
+}
+
+// visitFrames resolves program counters into frames, innermost first, until visit returns false.
+// Every resolution goes through here, so that it is bounded by SetSymbolizationConcurrency().
+func visitFrames(pc []uintptr, visit func(frame runtime.Frame) bool) {
+	if len(pc) == 0 {
+		return
+	}
+
+	semaphore := symbolizationSemaphore.Load().(chan struct{})
+	semaphore <- struct{}{}
+	defer func() { <-semaphore }()
+
+	frames := runtime.CallersFrames(pc)
+	for {
+		frame, more := frames.Next()
+		if !visit(frame) || !more {
+			return
+		}
 	}
 }
 
@@ -126,11 +403,19 @@ func (st *stackTrace) deduplicateFramesWithCause() ([]uintptr, int) {
 	pc := st.pc
 	causePC := st.causeStackTrace.pc
 
-	for i := 1; i <= len(pc) && i <= len(causePC); i++ {
+	limit := len(pc)
+	if st.keepFirstFrame && limit > 0 {
+		limit--
+	}
+
+	for i := 1; i <= limit && i <= len(causePC); i++ {
 		if pc[len(pc)-i] != causePC[len(causePC)-i] {
 			return pc[:len(pc)-i], i - 1
 		}
 	}
 
-	return nil, len(pc)
+	if limit > len(causePC) {
+		limit = len(causePC)
+	}
+	return pc[:len(pc)-limit], limit
 }
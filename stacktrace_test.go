@@ -8,6 +8,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -240,3 +241,225 @@ func readByLine(t *testing.T, output string, f func(string)) {
 		f(string(lineBytes))
 	}
 }
+
+func TestStackTraceConcurrentFormat(t *testing.T) {
+	err := stackTestStart()
+
+	outputs := make(chan string, 8)
+	for i := 0; i < cap(outputs); i++ {
+		go func() {
+			outputs <- fmt.Sprintf("%+v", err)
+		}()
+	}
+
+	reference := fmt.Sprintf("%+v", err)
+	for i := 0; i < cap(outputs); i++ {
+		require.Equal(t, reference, <-outputs)
+	}
+}
+
+func TestSymbolizationConcurrency(t *testing.T) {
+	SetSymbolizationConcurrency(1)
+	defer SetSymbolizationConcurrency(0)
+
+	err := testType.New("test")
+
+	// with the only slot taken, no resolution of frames may proceed
+	semaphore := symbolizationSemaphore.Load().(chan struct{})
+	semaphore <- struct{}{}
+
+	resolved := make(chan bool)
+	go func() {
+		_, _, _, ok := err.Origin()
+		resolved <- ok
+	}()
+
+	select {
+	case <-resolved:
+		require.Fail(t, "origin resolved while symbolization was blocked")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	<-semaphore
+	require.True(t, <-resolved)
+}
+
+func TestOrigin(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		file, line, function, ok := testType.New("test").Origin()
+		require.True(t, ok)
+		require.True(t, strings.HasSuffix(file, "/stacktrace_test.go"), file)
+		require.True(t, line > 0)
+		require.Equal(t, "github.com/joomcode/errorx.TestOrigin.func1", function)
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		err := createErrorFuncInStackTrace(testType)
+		_, _, function, ok := Decorate(err, "decorated").Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.createErrorFuncInStackTrace", function)
+	})
+
+	t.Run("CreatedInsideErrorx", func(t *testing.T) {
+		defer SnapshotRegistry()()
+
+		errorType := NewNamespace("TestOrigin").NewType("origin")
+		require.NoError(t, errorType.RegisterAlias("TestOrigin.alias"))
+		err := NewNamespace("TestOrigin").NewType("other").RegisterAlias("TestOrigin.alias")
+
+		_, _, function, ok := Cast(err).Origin()
+		require.True(t, ok)
+		require.Equal(t, "github.com/joomcode/errorx.TestOrigin.func3", function)
+	})
+
+	t.Run("Transformer", func(t *testing.T) {
+		defer SnapshotRegistry()()
+
+		_, _ = InitializeStackTraceTransformer(func(file string) string {
+			return file[strings.LastIndex(file, "/")+1:]
+		})
+
+		file, _, _, ok := testType.New("test").Origin()
+		require.True(t, ok)
+		require.Equal(t, "stacktrace_test.go", file)
+	})
+
+	t.Run("NoStackTrace", func(t *testing.T) {
+		_, _, _, ok := testTypeSilent.New("test").Origin()
+		require.False(t, ok)
+	})
+}
+
+func TestCompareStacks(t *testing.T) {
+	t.Run("DifferentCallSites", func(t *testing.T) {
+		first := createErrorFuncInStackTrace(testType)
+		second := createErrorFuncInStackTrace(testType)
+
+		common, ok := CompareStacks(first, second)
+		require.True(t, ok)
+		// the shared helper frame does not count, as the call sites diverge before it
+		require.Equal(t, len(first.stackTrace.locations())-2, common)
+		require.True(t, common > 0)
+	})
+
+	t.Run("SameError", func(t *testing.T) {
+		err := createErrorFuncInStackTrace(testType)
+
+		common, ok := CompareStacks(err, Decorate(err, "decorated"))
+		require.True(t, ok)
+		require.Equal(t, len(err.stackTrace.locations()), common)
+	})
+
+	t.Run("NoStackTrace", func(t *testing.T) {
+		_, ok := CompareStacks(testType.New("test"), testTypeSilent.New("test"))
+		require.False(t, ok)
+
+		_, ok = CompareStacks(errors.New("test"), testType.New("test"))
+		require.False(t, ok)
+	})
+}
+
+func TestStackTraceSampling(t *testing.T) {
+	t.Run("Never", func(t *testing.T) {
+		SetStackTraceSampling(0)
+		defer SetStackTraceSampling(1)
+
+		for i := 0; i < 100; i++ {
+			require.Nil(t, testType.New("test").stackTrace)
+			require.Nil(t, testType.Wrap(errors.New("cause"), "test").stackTrace)
+		}
+
+		output := fmt.Sprintf("%+v", testType.New("test"))
+		require.Equal(t, "foo.bar: test", output)
+	})
+
+	t.Run("Always", func(t *testing.T) {
+		SetStackTraceSampling(1)
+
+		for i := 0; i < 100; i++ {
+			require.NotNil(t, testType.New("test").stackTrace)
+			require.NotNil(t, testType.Wrap(errors.New("cause"), "test").stackTrace)
+		}
+	})
+
+	t.Run("Enhance", func(t *testing.T) {
+		cause := testType.New("cause")
+
+		SetStackTraceSampling(0)
+		defer SetStackTraceSampling(1)
+
+		err := NewErrorBuilder(testType).WithCause(cause).EnhanceStackTrace().Create()
+		require.True(t, err.stackTrace == cause.stackTrace)
+		require.Nil(t, NewErrorBuilder(testType).WithCause(errors.New("cause")).EnhanceStackTrace().Create().stackTrace)
+	})
+
+	t.Run("Fraction", func(t *testing.T) {
+		SetStackTraceSampling(0.5)
+		defer SetStackTraceSampling(1)
+
+		sampled := 0
+		for i := 0; i < 1000; i++ {
+			if testType.New("test").stackTrace != nil {
+				sampled++
+			}
+		}
+		require.True(t, sampled > 0 && sampled < 1000, sampled)
+	})
+}
+
+func TestHasStackTrace(t *testing.T) {
+	t.Run("Collected", func(t *testing.T) {
+		require.True(t, testType.New("test").HasStackTrace())
+		require.True(t, Decorate(testType.New("test"), "decorated").HasStackTrace())
+		require.True(t, Adopt(errors.New("test")).HasStackTrace())
+	})
+
+	t.Run("Omitted", func(t *testing.T) {
+		require.False(t, NewErrorBuilder(testType).WithoutStackTrace().Create().HasStackTrace())
+		require.False(t, testTypeSilent.New("test").HasStackTrace())
+	})
+
+	t.Run("SampledOut", func(t *testing.T) {
+		SetStackTraceSampling(0)
+		defer SetStackTraceSampling(1)
+
+		require.False(t, testType.New("test").HasStackTrace())
+	})
+}
+
+func TestStackTraceStyleGoPanic(t *testing.T) {
+	SetStackTraceStyle(StackTraceStyleGoPanic)
+	defer SetStackTraceStyle(StackTraceStyleErrorx)
+
+	t.Run("Simple", func(t *testing.T) {
+		output := fmt.Sprintf("%+v", createErrorFuncInStackTrace(testType))
+		require.Regexp(t, `^foo\.bar\n\ngoroutine 0 \[running\]:\n`+
+			`github\.com/joomcode/errorx\.createErrorFuncInStackTrace\(\.\.\.\)\n\t.*/error_test\.go:\d+( \+0x[0-9a-f]+)?\n`+
+			`github\.com/joomcode/errorx\.TestStackTraceStyleGoPanic\.func1\(\.\.\.\)\n\t.*/stacktrace_test\.go:\d+( \+0x[0-9a-f]+)?\n`, output)
+		require.Regexp(t, `\A([^\n]*\n\ngoroutine 0 \[running\]:)(\n[^\t\n][^\n]*\(\.\.\.\)\n\t[^\n]+:\d+( \+0x[0-9a-f]+)?)+\z`, output)
+		require.NotContains(t, output, " at ", output)
+	})
+
+	t.Run("Enhanced", func(t *testing.T) {
+		errCh := make(chan error)
+		go func() {
+			errCh <- createErrorFuncInStackTrace(testType)
+		}()
+
+		output := fmt.Sprintf("%+v", EnhanceStackTrace(<-errCh, "enhanced"))
+		require.Equal(t, 2, strings.Count(output, "\n\ngoroutine 0 [running]:\n"), output)
+		require.NotContains(t, output, "----", output)
+	})
+
+	t.Run("EnhancedInSameGoroutine", func(t *testing.T) {
+		cause := createErrorFuncInStackTrace(testType)
+		err := EnhanceStackTrace(cause, "enhanced")
+		// an enhancement at a point which is already in the cause stack trace, so that every frame of it is a duplicate
+		err.stackTrace = &stackTrace{pc: cause.stackTrace.pc[1:], causeStackTrace: cause.stackTrace}
+
+		output := fmt.Sprintf("%+v", err)
+		require.Equal(t, 1, strings.Count(output, "\n\ngoroutine 0 [running]:\n"), output)
+		require.NotContains(t, output, "elided", output)
+		require.Contains(t, output, "createErrorFuncInStackTrace", output)
+	})
+}
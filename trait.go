@@ -14,18 +14,69 @@ func RegisterTrait(label string) Trait {
 	return newTrait(label)
 }
 
+// Name returns a label the trait was registered with.
+// For a zero value of a Trait, returns "unknown".
+// Note that labels are not guaranteed to be unique, so the name is only meant to describe a trait, say, in logs or metrics.
+func (t Trait) Name() string {
+	if t.id == 0 {
+		return "unknown"
+	}
+	return t.label
+}
+
 // HasTrait checks if an error possesses the expected trait.
 // Traits are always properties of a type rather than of an instance, so trait check is an alternative to a type check.
 // This alternative is preferable, though, as it is less brittle and generally creates less of a dependency.
+// A non-errorx error may only possess a trait recognised by an adapter, see RegisterErrorAdapter.
 func HasTrait(err error, key Trait) bool {
 	typedErr := Cast(err)
 	if typedErr == nil {
-		return false
+		return err != nil && hasAdaptedTrait(err, key)
 	}
 
 	return typedErr.HasTrait(key)
 }
 
+// IsTrait checks if an error possesses the expected trait, looking through the whole chain of wrapped errors.
+// Unlike HasTrait, which only checks an errorx error itself, it also follows Unwrap() of non-errorx errors,
+// so that a trait is found even if an errorx error is wrapped, say, with fmt.Errorf("%w").
+// A trait check of an errorx error found in a chain works just as HasTrait would, so opaque wrap still hides the traits of the cause.
+func IsTrait(err error, key Trait) bool {
+	for err != nil {
+		typedErr := Cast(err)
+		if typedErr == nil {
+			if hasForeignTrait(err, key) {
+				return true
+			}
+
+			wrapper, ok := err.(interface{ Unwrap() error })
+			if !ok {
+				return false
+			}
+
+			err = wrapper.Unwrap()
+			continue
+		}
+
+		for typedErr.transparent && Cast(typedErr.Cause()) != nil {
+			typedErr = Cast(typedErr.Cause())
+		}
+
+		if !typedErr.transparent {
+			if typedErr.errorType.HasTrait(key) {
+				return true
+			}
+			if !typedErr.propagatesTrait(key) {
+				return false
+			}
+		}
+
+		err = typedErr.Cause()
+	}
+
+	return false
+}
+
 // Temporary is a trait that signifies that an error is temporary in nature.
 func Temporary() Trait { return traitTemporary }
 
@@ -38,6 +89,10 @@ func NotFound() Trait { return traitNotFound }
 // Duplicate is a trait that marks such an error where an update is failed as a duplicate.
 func Duplicate() Trait { return traitDuplicate }
 
+// Expected is a trait that marks such an error which is a part of normal operation, rather than a sign of a bug or a failure.
+// Typical examples are validation errors or business rule violations. Errors without this trait are considered unexpected.
+func Expected() Trait { return traitExpected }
+
 // IsTemporary checks for Temporary trait.
 func IsTemporary(err error) bool {
 	return HasTrait(err, Temporary())
@@ -58,13 +113,29 @@ func IsDuplicate(err error) bool {
 	return HasTrait(err, Duplicate())
 }
 
+// IsExpected checks for Expected trait.
+// This may be used, for example, to decide whether or not an error requires an alert.
+func IsExpected(err error) bool {
+	return HasTrait(err, Expected())
+}
+
 var (
 	traitTemporary = RegisterTrait("temporary")
 	traitTimeout   = RegisterTrait("timeout")
 	traitNotFound  = RegisterTrait("not_found")
 	traitDuplicate = RegisterTrait("duplicate")
+	traitExpected  = RegisterTrait("expected")
 )
 
+// hasForeignTrait recognises a trait of a non-errorx error, where possible, see RegisterErrorAdapter.
+func hasForeignTrait(err error, key Trait) bool {
+	if key == traitNotFound && isNotExistError(err) {
+		return true
+	}
+
+	return hasAdaptedTrait(err, key)
+}
+
 func newTrait(label string) Trait {
 	return Trait{
 		id:    nextInternalID(),
@@ -1,6 +1,7 @@
 package errorx
 
 import (
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -59,6 +60,55 @@ func TestTrait(t *testing.T) {
 		require.True(t, IsTimeout(err))
 		require.False(t, IsTemporary(err))
 	})
+
+	t.Run("DecorateTwice", func(t *testing.T) {
+		err := Decorate(Decorate(TimeoutElapsed.New("test"), "inner"), "outer")
+
+		require.True(t, err.HasTrait(Timeout()))
+		require.True(t, HasTrait(err, Timeout()))
+		require.False(t, HasTrait(InternalError.Wrap(err, "opaque"), Timeout()))
+	})
+
+	t.Run("PropagatingTraits", func(t *testing.T) {
+		cause := Decorate(traitTestTemporaryTimeoutError.New("test"), "decorated")
+		err := NewErrorBuilder(traitTestError2).
+			WithCause(cause).
+			PropagatingTraits(Timeout(), NotFound()).
+			Create()
+
+		require.True(t, IsTimeout(err))
+		require.True(t, IsTimeout(Decorate(err, "decorated")))
+		require.True(t, IsTrait(err, Timeout()))
+		require.False(t, IsTemporary(err))
+		require.False(t, IsNotFound(err))
+		require.True(t, HasTrait(err, testTrait2))
+		require.False(t, IsOfType(err, traitTestTemporaryTimeoutError))
+
+		_, primaryTrait, _ := MetricLabels(err)
+		require.Equal(t, "timeout", primaryTrait)
+		require.Equal(t, map[string]int{"test0": 1, "test2": 1, "timeout": 1}, Summarize([]error{err}).ByTrait)
+
+		// propagation is a part of an error itself, and is neither a property nor merged with them
+		err.VisitProperties(func(Property, interface{}) {
+			require.Fail(t, "unexpected property")
+		})
+		require.False(t, IsTimeout(traitTestError2.Wrap(cause, "").MergePropertiesFrom(err)))
+		require.True(t, IsTimeout(err.Clone()))
+	})
+
+	t.Run("PropagatingForeignTraits", func(t *testing.T) {
+		err := NewErrorBuilder(traitTestError2).
+			WithCause(&os.PathError{Op: "open", Path: "/tmp/users", Err: os.ErrNotExist}).
+			PropagatingTraits(NotFound()).
+			Create()
+
+		require.True(t, IsNotFound(err))
+		require.True(t, IsTrait(err, NotFound()))
+	})
+
+	t.Run("PropagatingTraitsWithoutCause", func(t *testing.T) {
+		require.Panics(t, func() { NewErrorBuilder(traitTestError2).PropagatingTraits(Timeout()) })
+	})
 }
 
 func TestTraitNamespace(t *testing.T) {
@@ -83,3 +133,39 @@ func TestTraitNamespace(t *testing.T) {
 		require.True(t, HasTrait(err, testTrait2))
 	})
 }
+
+func TestTraitName(t *testing.T) {
+	t.Run("BuiltIn", func(t *testing.T) {
+		require.Equal(t, "temporary", Temporary().Name())
+		require.Equal(t, "timeout", Timeout().Name())
+		require.Equal(t, "not_found", NotFound().Name())
+		require.Equal(t, "duplicate", Duplicate().Name())
+	})
+
+	t.Run("Registered", func(t *testing.T) {
+		require.Equal(t, "test0", testTrait0.Name())
+	})
+
+	t.Run("Unknown", func(t *testing.T) {
+		require.Equal(t, "unknown", Trait{}.Name())
+	})
+}
+
+func TestExpected(t *testing.T) {
+	t.Run("Expected", func(t *testing.T) {
+		require.True(t, IsExpected(IllegalArgument.New("test")))
+		require.True(t, IsExpected(IllegalFormat.New("test")))
+		require.True(t, IsExpected(RejectedOperation.New("test")))
+		require.True(t, IsExpected(NewDuplicate("test")))
+		require.True(t, IsExpected(Decorate(IllegalArgument.New("test"), "decorated")))
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		require.False(t, IsExpected(InternalError.New("test")))
+		require.False(t, IsExpected(AssertionFailed.New("test")))
+		require.False(t, IsExpected(IllegalState.New("test")))
+		require.False(t, IsExpected(ExternalError.New("test")))
+		require.False(t, IsExpected(InternalError.Wrap(IllegalArgument.New("test"), "wrapped")))
+		require.False(t, IsExpected(testType.New("test")))
+	})
+}
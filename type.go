@@ -2,6 +2,10 @@ package errorx
 
 import (
 	"encoding"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
 // Type is a distinct error type.
@@ -14,7 +18,10 @@ type Type struct {
 	id        uint64
 	fullName  string
 	traits    map[Trait]bool
+	ownTraits []Trait
 	modifiers modifiers
+	helpURL   string
+	hooks     atomic.Value // []createHook
 }
 
 var _ encoding.TextMarshaler = (*Type)(nil)
@@ -35,13 +42,136 @@ func (t *Type) ApplyModifiers(modifiers ...TypeModifier) *Type {
 	return t
 }
 
+// WithHelpURL provides a link to a documentation on an error type, say, a runbook with remediation steps.
+// The link is inherited by all subtypes which do not provide their own, see HelpURL().
+// It is also included in a full (%+v) output of an error.
+// Just like ApplyModifiers, it is meant to be used once along with type declaration.
+func (t *Type) WithHelpURL(url string) *Type {
+	t.helpURL = url
+	return t
+}
+
+// HelpURL returns a link to a documentation on an error type, either its own or inherited from a supertype.
+func (t *Type) HelpURL() (string, bool) {
+	for current := t; current != nil; current = current.parent {
+		if current.helpURL != "" {
+			return current.helpURL, true
+		}
+	}
+
+	return "", false
+}
+
+// OnCreate registers a hook to be called upon a creation of every error of this type, say, to count failures for a circuit breaker.
+// With includeSubtypes, the hook is also called for errors of all subtypes, including those declared later.
+// Hooks are called in Create() of ErrorBuilder, and therefore in New(), Wrap() and the like, but not in Decorate(), which creates no error of a type.
+// Hooks of an exact type are called first, then those of its supertypes, from the closest one, each in the order of registration.
+// A hook is called synchronously on a goroutine which creates an error, possibly concurrently with other calls, and must be safe for it.
+// It is safe to register hooks concurrently with an error creation, but it is meant to be done once, along with type declaration.
+func (t *Type) OnCreate(hook func(*Error), includeSubtypes bool) *Type {
+	createHooksMu.Lock()
+	defer createHooksMu.Unlock()
+
+	hooks, _ := t.hooks.Load().([]createHook)
+	t.hooks.Store(append(hooks[:len(hooks):len(hooks)], createHook{hook: hook, includeSubtypes: includeSubtypes}))
+	return t
+}
+
+type createHook struct {
+	hook            func(*Error)
+	includeSubtypes bool
+}
+
+var createHooksMu sync.Mutex
+
+// snapshotCreateHooks captures the hooks of the types, see SnapshotRegistry.
+func snapshotCreateHooks(types []*Type) func() {
+	createHooksMu.Lock()
+	defer createHooksMu.Unlock()
+
+	hooks := make([][]createHook, len(types))
+	for i, t := range types {
+		hooks[i], _ = t.hooks.Load().([]createHook)
+	}
+
+	return func() {
+		createHooksMu.Lock()
+		defer createHooksMu.Unlock()
+
+		for i, t := range types {
+			t.hooks.Store(hooks[i])
+		}
+	}
+}
+
+func runCreateHooks(err *Error) {
+	for current := err.errorType; current != nil; current = current.parent {
+		hooks, _ := current.hooks.Load().([]createHook)
+		for _, h := range hooks {
+			if h.includeSubtypes || current == err.errorType {
+				h.hook(err)
+			}
+		}
+	}
+}
+
+// RegisterAlias makes a type discoverable with TypeByName() under an additional full name, typically the one it had before a rename.
+// This allows the errors serialized by an older version of a service to be reconstructed.
+// Alias must be unique: an error is returned if it is a name of another registered type, or an alias of another type.
+func (t *Type) RegisterAlias(oldFullName string) error {
+	return globalRegistry.registerTypeAlias(t, oldFullName)
+}
+
 // New creates an error of this type with a message.
 // Without args, leaves the original message intact, so a message may be generated or provided externally.
 // With args, a formatting is performed, and it is therefore expected a format string to be constant.
 func (t *Type) New(message string, args ...interface{}) *Error {
-	return NewErrorBuilder(t).
+	err := NewErrorBuilder(t).
 		WithConditionallyFormattedMessage(message, args...).
 		Create()
+	warnOnEmptyMessage(err)
+	return err
+}
+
+// NewWith creates an error of this type with a message and a number of options, see Option.
+// This is a more concise alternative to ErrorBuilder, and it is equivalent to the builder with the same options applied in order.
+// Message is used as is, with no formatting performed.
+func (t *Type) NewWith(message string, opts ...Option) *Error {
+	builder := NewErrorBuilder(t).WithConditionallyFormattedMessage(message)
+	for _, opt := range opts {
+		builder = opt(builder)
+	}
+
+	err := builder.Create()
+	warnOnEmptyMessage(err)
+	return err
+}
+
+// SetWarnOnEmptyMessage sets a callback to be called whenever New() or NewWith() creates an error with an empty message and no cause,
+// as such an error is described by its type name alone, which is most likely an accident. NewWithNoMessage() is not affected.
+// The callback receives a new error, and may, say, log its full (%+v) output, complete with a stack trace:
+//
+//	errorx.SetWarnOnEmptyMessage(func(err *errorx.Error) { log.Printf("error created with an empty message: %+v", err) })
+//
+// The callback is called synchronously, possibly concurrently with other calls.
+// This is meant to be enabled in development and tests. A nil callback disables the check, which is the default.
+func SetWarnOnEmptyMessage(onEmpty func(*Error)) {
+	emptyMessageWarning.Store(emptyMessageCallback{onEmpty: onEmpty})
+}
+
+type emptyMessageCallback struct {
+	onEmpty func(*Error)
+}
+
+var emptyMessageWarning atomic.Value // emptyMessageCallback
+
+func warnOnEmptyMessage(err *Error) {
+	callback, ok := emptyMessageWarning.Load().(emptyMessageCallback)
+	if !ok || callback.onEmpty == nil || err.message != "" || err.cause != nil {
+		return
+	}
+
+	callback.onEmpty(err)
 }
 
 // NewWithNoMessage creates an error of this type without any message.
@@ -94,6 +224,66 @@ func (t *Type) HasTrait(key Trait) bool {
 	return ok
 }
 
+// TraitNames returns the labels of all the traits this type possesses, sorted alphabetically.
+// This includes the traits inherited from a supertype and from a namespace.
+// May be used to describe an error type, say, in generated documentation; use HasTrait for an actual trait check.
+func (t *Type) TraitNames() []string {
+	names := make([]string, 0, len(t.traits))
+	for trait := range t.traits {
+		names = append(names, trait.label)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// Describe returns a human-readable description of a type: its name, supertype, namespace, traits and a help URL, if any.
+// Each trait is labeled with its source, which is either the type itself, its supertype, or its namespace:
+//
+//	type: traits2.simple.sub
+//	supertype: traits2.simple
+//	namespace: traits2
+//	traits: test0 (namespace), test2 (inherited), timeout (own)
+//
+// This is a tool to inspect a hierarchy of error types, and the output format is not meant to be parsed.
+func (t *Type) Describe() string {
+	lines := []string{"type: " + t.FullName()}
+	if t.parent != nil {
+		lines = append(lines, "supertype: "+t.parent.FullName())
+	}
+	lines = append(lines, "namespace: "+t.namespace.FullName())
+
+	if len(t.traits) > 0 {
+		traits := make([]string, 0, len(t.traits))
+		for trait := range t.traits {
+			traits = append(traits, trait.label+" ("+t.traitSource(trait)+")")
+		}
+
+		sort.Strings(traits)
+		lines = append(lines, "traits: "+strings.Join(traits, ", "))
+	}
+
+	if url, ok := t.HelpURL(); ok {
+		lines = append(lines, "help: "+url)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (t *Type) traitSource(trait Trait) string {
+	for _, own := range t.ownTraits {
+		if own == trait {
+			return "own"
+		}
+	}
+
+	if t.namespace.collectTraits()[trait] {
+		return "namespace"
+	}
+
+	return "inherited"
+}
+
 // IsOfType is a type check for errors.
 // Returns true either if both are of exactly the same type, or if the same is true for one of current type's ancestors.
 // For an error that does not have an errorx type, returns false.
@@ -102,6 +292,18 @@ func IsOfType(err error, t *Type) bool {
 	return e != nil && e.IsOfType(t)
 }
 
+// HelpURL returns a link to a documentation on the error type, see Type.WithHelpURL().
+// For decorated errors, the type of an original cause is used.
+// For an error that does not have an errorx type, returns false.
+func HelpURL(err error) (string, bool) {
+	e := Cast(err)
+	if e == nil {
+		return "", false
+	}
+
+	return e.Type().HelpURL()
+}
+
 // Supertype returns a parent type, if present.
 func (t *Type) Supertype() *Type {
 	return t.parent
@@ -175,6 +377,7 @@ func newType(namespace Namespace, parent *Type, name string, traits ...Trait) *T
 		parent:    parent,
 		fullName:  createFullName(),
 		traits:    collectTraits(),
+		ownTraits: append([]Trait(nil), traits...),
 		modifiers: collectModifiers(),
 	}
 
@@ -2,6 +2,7 @@ package errorx
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,20 @@ func TestErrorTypeDownCast(t *testing.T) {
 	require.False(t, testType.IsOfType(testSubtype1))
 }
 
+func TestErrorIsOfTypeName(t *testing.T) {
+	err := testSubtype1.New("test")
+	require.True(t, err.IsOfTypeName("foo.bar.internal.wat"))
+	require.True(t, err.IsOfTypeName("foo.bar.internal"))
+	require.True(t, err.IsOfTypeName("foo.bar"))
+	require.False(t, err.IsOfTypeName("foo"))
+	require.False(t, err.IsOfTypeName("foo.bar.internal.wat.more"))
+	require.False(t, testSubtype0.New("test").IsOfTypeName("foo.bar.internal.wat"))
+
+	require.True(t, Decorate(err, "decorated").IsOfTypeName("foo.bar"))
+	require.False(t, testTypeBar1.Wrap(err, "wrapped").IsOfTypeName("foo.bar"))
+	require.False(t, Decorate(errors.New("test"), "decorated").IsOfTypeName("foo.bar"))
+}
+
 func TestErrorTypeSiblingsCast(t *testing.T) {
 	subtype10 := testSubtype0.NewSubtype("wat!")
 	subtype11 := testSubtype0.NewSubtype("oops")
@@ -41,3 +56,174 @@ func TestErrorTypeSiblingsCast(t *testing.T) {
 	require.False(t, subtype10.IsOfType(subtype11))
 	require.False(t, subtype11.IsOfType(subtype10))
 }
+
+func TestTypeTraitNames(t *testing.T) {
+	t.Run("NoTraits", func(t *testing.T) {
+		require.Empty(t, testType.TraitNames())
+	})
+
+	t.Run("Own", func(t *testing.T) {
+		require.Equal(t, []string{"timeout"}, TimeoutElapsed.TraitNames())
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		subtype := traitTestError3.NewSubtype("sub", Timeout())
+		require.Equal(t, []string{"test0", "test1", "test2", "timeout"}, subtype.TraitNames())
+	})
+}
+
+func TestTypeHelpURL(t *testing.T) {
+	helpNamespace := NewNamespace("help")
+	documented := helpNamespace.NewType("documented").WithHelpURL("https://example.com/runbook#documented")
+	inherited := documented.NewSubtype("inherited")
+	overridden := documented.NewSubtype("overridden").WithHelpURL("https://example.com/runbook#overridden")
+	undocumented := helpNamespace.NewType("undocumented")
+
+	t.Run("Direct", func(t *testing.T) {
+		url, ok := HelpURL(documented.New("test"))
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/runbook#documented", url)
+	})
+
+	t.Run("Inherited", func(t *testing.T) {
+		url, ok := HelpURL(inherited.New("test"))
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/runbook#documented", url)
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		url, ok := HelpURL(overridden.New("test"))
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/runbook#overridden", url)
+	})
+
+	t.Run("Decorated", func(t *testing.T) {
+		url, ok := HelpURL(Decorate(inherited.New("test"), "decorated"))
+		require.True(t, ok)
+		require.Equal(t, "https://example.com/runbook#documented", url)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, ok := HelpURL(undocumented.New("test"))
+		require.False(t, ok)
+
+		_, ok = HelpURL(errors.New("test"))
+		require.False(t, ok)
+	})
+
+	t.Run("Format", func(t *testing.T) {
+		err := inherited.New("test")
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "help.documented.inherited: test\n see: https://example.com/runbook#documented\n at ", output)
+		require.NotContains(t, fmt.Sprintf("%v", err), "see:")
+		require.NotContains(t, fmt.Sprintf("%+v", undocumented.New("test")), "see:")
+	})
+}
+
+func TestTypeDescribe(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		require.Equal(t, "type: foo.bar\nnamespace: foo", testType.Describe())
+	})
+
+	t.Run("Subtype", func(t *testing.T) {
+		subtype := traitTestError2.NewSubtype("described", Timeout()).WithHelpURL("https://example.com/runbook")
+		expected := "type: traits2.simple.described\n" +
+			"supertype: traits2.simple\n" +
+			"namespace: traits2\n" +
+			"traits: test0 (namespace), test2 (inherited), timeout (own)\n" +
+			"help: https://example.com/runbook"
+		require.Equal(t, expected, subtype.Describe())
+	})
+
+	t.Run("SubNamespace", func(t *testing.T) {
+		require.Equal(t, "type: traits2.child.simple\nnamespace: traits2.child\ntraits: test0 (namespace), test1 (namespace), test2 (own)", traitTestError3.Describe())
+	})
+}
+
+func TestWarnOnEmptyMessage(t *testing.T) {
+	var warned []*Error
+	onEmpty := func(err *Error) { warned = append(warned, err) }
+
+	t.Run("Disabled", func(t *testing.T) {
+		warned = nil
+		_ = testType.New("")
+		require.Empty(t, warned)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		SetWarnOnEmptyMessage(onEmpty)
+		defer SetWarnOnEmptyMessage(nil)
+
+		warned = nil
+		err := testType.New("")
+		require.Equal(t, []*Error{err}, warned)
+		require.Contains(t, fmt.Sprintf("%+v", warned[0]), "TestWarnOnEmptyMessage")
+
+		warned = nil
+		_ = testType.New("%s", "")
+		_ = testType.NewWith("")
+		require.Len(t, warned, 2)
+	})
+
+	t.Run("Legitimate", func(t *testing.T) {
+		SetWarnOnEmptyMessage(onEmpty)
+		defer SetWarnOnEmptyMessage(nil)
+
+		warned = nil
+		_ = testType.New("message")
+		_ = testType.NewWithNoMessage()
+		_ = testType.NewWith("", WithCauseOpt(errors.New("cause")))
+		_ = testType.Wrap(errors.New("cause"), "")
+		require.Empty(t, warned)
+	})
+}
+
+func TestTypeOnCreate(t *testing.T) {
+	hookNamespace := NewNamespace("hook")
+	parent := hookNamespace.NewType("parent")
+	child := parent.NewSubtype("child")
+
+	var calls []string
+	parent.OnCreate(func(err *Error) { calls = append(calls, "parent: "+err.Message()) }, true)
+	parent.OnCreate(func(err *Error) { calls = append(calls, "parent only: "+err.Message()) }, false)
+	child.OnCreate(func(err *Error) { calls = append(calls, "child: "+err.Message()) }, false)
+
+	t.Run("ExactType", func(t *testing.T) {
+		calls = nil
+		_ = parent.New("test")
+		require.Equal(t, []string{"parent: test", "parent only: test"}, calls)
+	})
+
+	t.Run("Subtype", func(t *testing.T) {
+		calls = nil
+		_ = child.Wrap(errors.New("cause"), "test")
+		require.Equal(t, []string{"child: test", "parent: test"}, calls)
+	})
+
+	t.Run("Decorate", func(t *testing.T) {
+		err := parent.New("test")
+
+		calls = nil
+		_ = Decorate(err, "decorated")
+		require.Empty(t, calls)
+	})
+
+	t.Run("OtherType", func(t *testing.T) {
+		calls = nil
+		_ = hookNamespace.NewType("other").New("test")
+		require.Empty(t, calls)
+	})
+
+	t.Run("Properties", func(t *testing.T) {
+		var seen []*Error
+		propertied := hookNamespace.NewType("propertied").OnCreate(func(err *Error) { seen = append(seen, err) }, false)
+
+		err := propertied.NewWith("test", WithPropertyOpt(testInfoProperty2, 42))
+		require.Len(t, seen, 1)
+		require.True(t, seen[0] == err)
+
+		value, ok := seen[0].Property(testInfoProperty2)
+		require.True(t, ok)
+		require.Equal(t, 42, value)
+	})
+}
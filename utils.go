@@ -1,6 +1,10 @@
 package errorx
 
+import "fmt"
+
 // Cast attempts to cast an error to errorx Type, returns nil if cast has failed.
+// Only the error itself is checked, which makes it a cheap operation suitable for any hot path.
+// If an errorx error may be wrapped by some non-errorx error, say, with fmt.Errorf("%w"), use CastDeep instead.
 func Cast(err error) *Error {
 	if e, ok := err.(*Error); ok && e != nil {
 		return e
@@ -9,6 +13,41 @@ func Cast(err error) *Error {
 	return nil
 }
 
+// SafeFormat returns a full (%+v) output of an error, complete with a stack trace, or an empty string if there is no error.
+// Unlike a plain fmt.Sprintf("%+v", err), it treats a nil *Error stored in a non-nil error interface the same as nil.
+// This is designed for a logging middleware, which receives an error it knows nothing about.
+func SafeFormat(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	if e, ok := err.(*Error); ok && e == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%+v", err)
+}
+
+// CastDeep attempts to find an errorx error in a chain of wrapped errors, returns nil if there is none.
+// Unlike Cast, it follows Unwrap() of non-errorx errors until an errorx error is found.
+// Note that the result is the outermost errorx error in a chain, which is not necessarily the original cause.
+func CastDeep(err error) *Error {
+	for err != nil {
+		if e := Cast(err); e != nil {
+			return e
+		}
+
+		wrapper, ok := err.(interface{ Unwrap() error })
+		if !ok {
+			return nil
+		}
+
+		err = wrapper.Unwrap()
+	}
+
+	return nil
+}
+
 // Ignore returns nil if an error is of one of the provided types, returns the provided error otherwise.
 // May be used if a particular error signifies a mark in control flow rather than an error to be reported to the caller.
 func Ignore(err error, types ...*Type) error {
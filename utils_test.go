@@ -50,3 +50,69 @@ func TestGetTypeName(t *testing.T) {
 		require.EqualValues(t, "", GetTypeName(Decorate(errors.New("test"), "")))
 	})
 }
+
+func TestCastDeep(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		err := testType.New("test")
+		require.Equal(t, err, CastDeep(err))
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		require.Nil(t, CastDeep(nil))
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		require.Nil(t, CastDeep(errors.New("test")))
+	})
+
+	t.Run("Wrapped", func(t *testing.T) {
+		err := testType.New("test")
+		wrapped := wrappingError{wrappingError{err}}
+		require.Nil(t, Cast(wrapped))
+		require.Equal(t, err, CastDeep(wrapped))
+	})
+
+	t.Run("WrappedRaw", func(t *testing.T) {
+		require.Nil(t, CastDeep(wrappingError{errors.New("test")}))
+	})
+}
+
+type wrappingError struct {
+	cause error
+}
+
+func (w wrappingError) Error() string {
+	return "wrapped: " + w.cause.Error()
+}
+
+func (w wrappingError) Unwrap() error {
+	return w.cause
+}
+
+var castSink *Error
+
+func BenchmarkCast(b *testing.B) {
+	var err error = testTypeSilent.New("test")
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		castSink = Cast(err)
+	}
+}
+
+func BenchmarkCastDeep(b *testing.B) {
+	b.Run("Direct", func(b *testing.B) {
+		var err error = testTypeSilent.New("test")
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			castSink = CastDeep(err)
+		}
+	})
+
+	b.Run("Wrapped", func(b *testing.B) {
+		var err error = wrappingError{wrappingError{testTypeSilent.New("test")}}
+		b.ReportAllocs()
+		for n := 0; n < b.N; n++ {
+			castSink = CastDeep(err)
+		}
+	})
+}
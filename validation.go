@@ -0,0 +1,61 @@
+package errorx
+
+// NewValidationError creates a ValidationFailed error for an invalid field of a request, see FieldErrors.
+// Field may be a dotted path to a nested field, such as "user.address.zip", and message is meant to be shown to a client.
+func NewValidationError(field string, message string) *Error {
+	return NewErrorBuilder(ValidationFailed).
+		WithConditionallyFormattedMessage(message).
+		WithProperty(propertyField, field).
+		Create()
+}
+
+// ValidationErrors collects validation errors of all the fields of a request, so that a client receives them all at once.
+// ValidationErrors is not safe for concurrent use.
+type ValidationErrors struct {
+	errs []error
+}
+
+// Add records an invalid field, see NewValidationError.
+func (v *ValidationErrors) Add(field string, message string) {
+	v.errs = append(v.errs, NewValidationError(field, message))
+}
+
+// Result returns all recorded errors composed into a MultiError, or nil if there were none.
+func (v *ValidationErrors) Result() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return DecorateEach("validation failed", v.errs...)
+}
+
+// FieldErrors extracts a message for each invalid field, keyed by field, from a validation error or a MultiError composed of them.
+// A validation error is recognised through a transparent wrap, such as Decorate(), and its own message is used.
+// If the same field is invalid several times, the first message is used. Other errors are ignored.
+func FieldErrors(err error) map[string]string {
+	result := make(map[string]string)
+	collectFieldErrors(err, result)
+	return result
+}
+
+func collectFieldErrors(err error, result map[string]string) {
+	if multiErr, ok := err.(*MultiError); ok {
+		for _, e := range multiErr.errs {
+			collectFieldErrors(e, result)
+		}
+		return
+	}
+
+	for typedErr := Cast(err); typedErr != nil; typedErr = Cast(typedErr.Cause()) {
+		if field, ok := typedErr.OwnProperty(propertyField); ok {
+			if _, ok := result[field.(string)]; !ok {
+				result[field.(string)] = typedErr.Message()
+			}
+			return
+		}
+
+		if !typedErr.transparent {
+			return
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package errorx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewValidationError(t *testing.T) {
+	err := NewValidationError("user.address.zip", "must be 5 digits")
+	require.True(t, IsOfType(err, ValidationFailed))
+	require.True(t, IsOfType(err, IllegalArgument))
+	require.True(t, IsExpected(err))
+	require.Equal(t, "common.illegal_argument.validation: must be 5 digits {field: user.address.zip}", err.Error())
+	require.Equal(t, map[string]string{"user.address.zip": "must be 5 digits"}, FieldErrors(err))
+}
+
+func TestValidationErrors(t *testing.T) {
+	t.Run("Aggregate", func(t *testing.T) {
+		var v ValidationErrors
+		v.Add("user.name", "must not be empty")
+		v.Add("user.address.zip", "must be 5 digits")
+		v.Add("user.name", "must be shorter")
+		v.Add("user.email", "must be an email")
+
+		err := v.Result()
+		require.IsType(t, &MultiError{}, err)
+		require.Equal(t, map[string]string{
+			"user.name":        "must not be empty",
+			"user.address.zip": "must be 5 digits",
+			"user.email":       "must be an email",
+		}, FieldErrors(err))
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		var v ValidationErrors
+		require.NoError(t, v.Result())
+		require.Empty(t, FieldErrors(nil))
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		err := DecorateEach("request failed",
+			Decorate(NewValidationError("id", "must be positive"), "decorated"),
+			IllegalArgument.Wrap(NewValidationError("hidden", "wrapped"), "opaque"),
+			errors.New("test"),
+		)
+		require.Equal(t, map[string]string{"id": "must be positive"}, FieldErrors(err))
+	})
+}
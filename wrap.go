@@ -1,5 +1,10 @@
 package errorx
 
+import (
+	"sort"
+	"sync/atomic"
+)
+
 var (
 	// Most errors from this namespace are made private in order to disallow and direct type checks in the user code
 	syntheticErrors = NewNamespace("synthetic")
@@ -25,6 +30,27 @@ func Decorate(err error, message string, args ...interface{}) *Error {
 		Create()
 }
 
+// Wrapf decorates an error pointed to by errp, if there is one, see Decorate().
+// It is designed to be deferred in a function with a named error result,
+// so that every error returned from this function receives the same decoration:
+//
+//	func readConfig(path string) (cfg *Config, err error) {
+//		defer errorx.Wrapf(&err, "failed to read config %s", path)
+//		...
+//	}
+//
+// Does nothing if *errp is nil.
+func Wrapf(errp *error, message string, args ...interface{}) {
+	if *errp == nil {
+		return
+	}
+
+	*errp = NewErrorBuilder(transparentWrapper).
+		WithConditionallyFormattedMessage(message, args...).
+		WithCause(*errp).
+		Create()
+}
+
 // EnhanceStackTrace has all the properties of the Decorate() method
 // and additionally extends the stack trace of the original error.
 // Designed to be used when a original error is passed from another goroutine rather than from a direct method call.
@@ -52,6 +78,61 @@ func EnsureStackTrace(err error) *Error {
 		Create()
 }
 
+// Checkpoint marks a point where an error crosses an architectural boundary, say, from a repository to a service layer.
+// It extends the stack trace of an error with the current one, just as EnhanceStackTrace() does, but with no message of its own.
+// Called at each layer, this leaves a trail in a stack trace output, which tells exactly which layers the error has passed.
+// A non-errorx error is adopted at this point, see Adopt(). For a nil error, returns nil.
+func Checkpoint(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	checkpoint := NewErrorBuilder(stackTraceWrapper).
+		WithConditionallyFormattedMessage("").
+		WithCause(err).
+		EnhanceStackTrace().
+		Create()
+
+	// a checkpoint in the same goroutine would otherwise be cropped from the output as a duplicate of the cause stack trace
+	if typedErr := Cast(err); checkpoint.stackTrace != nil && (typedErr == nil || checkpoint.stackTrace != typedErr.stackTrace) {
+		checkpoint.stackTrace.keepFirstFrame = true
+	}
+	return checkpoint
+}
+
+// Adopt is a utility to bring a non-errorx error into errorx world.
+// If an error is already an errorx error, it is returned unmodified; for a nil error, returns nil.
+// Otherwise, it is wrapped transparently, and a stack trace is collected at this point.
+// Adopted error retains its original message, and some well-known conditions are recognised as traits,
+// for example, an error which matches os.ErrNotExist possesses a NotFound() trait.
+// Properties recognised by an adapter become the properties of an adopted error, see RegisterErrorAdapter.
+func Adopt(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	if typedErr := Cast(err); typedErr != nil {
+		return typedErr
+	}
+
+	builder := NewErrorBuilder(stackTraceWrapper).
+		WithCause(err)
+
+	if _, properties, ok := adaptError(err); ok {
+		keys := make([]Property, 0, len(properties))
+		for key := range properties {
+			keys = append(keys, key)
+		}
+
+		// a stable order of properties in an output
+		sort.Slice(keys, func(i, j int) bool { return keys[i].label > keys[j].label })
+		for _, key := range keys {
+			builder = builder.WithProperty(key, properties[key])
+		}
+	}
+	return builder.Create()
+}
+
 // DecorateMany performs a transparent wrap of multiple errors with additional message.
 // If there are no errors, or all errors are nil, returns nil.
 // If all errors are of the same type (for example, if there is only one), wraps them transparently.
@@ -113,3 +194,55 @@ func areAllOfTheSameType(errs ...error) bool {
 
 	return true
 }
+
+// WrapDepth returns a number of decorations in a chain of causes of an error, such as those made by Decorate(), EnhanceStackTrace() or Checkpoint().
+// Wrap with an error type, which is a meaningful translation of an error, is not counted, nor is Adopt(), Checkpoint() of a non-errorx error included.
+// An error decorated all over a call stack is a code smell, and this is a way to notice it, see SetMaxWrapDepth().
+func (e *Error) WrapDepth() int {
+	depth := 0
+	for cause := e; cause != nil; cause = Cast(cause.Cause()) {
+		if cause.isDecoration() {
+			depth++
+		}
+	}
+
+	return depth
+}
+
+// SetMaxWrapDepth provides a callback to be called upon a decoration of an error which makes its WrapDepth() exceed a threshold.
+// This may be used to report, say, in a log or a metric, the places where errors are wrapped excessively.
+// The callback receives a new decorated error, and is called synchronously, possibly concurrently with other calls.
+// Zero depth or a nil callback disables the check, which is the default, as it requires to walk a chain of causes upon each decoration.
+func SetMaxWrapDepth(depth int, onExceeded func(*Error)) {
+	maxWrapDepth.Store(wrapDepthLimit{depth: depth, onExceeded: onExceeded})
+}
+
+type wrapDepthLimit struct {
+	depth      int
+	onExceeded func(*Error)
+}
+
+var maxWrapDepth atomic.Value // wrapDepthLimit
+
+func checkWrapDepth(err *Error) {
+	limit, ok := maxWrapDepth.Load().(wrapDepthLimit)
+	if !ok || limit.depth <= 0 || limit.onExceeded == nil || !err.isDecoration() {
+		return
+	}
+
+	if err.WrapDepth() > limit.depth {
+		limit.onExceeded(err)
+	}
+}
+
+func (e *Error) isDecoration() bool {
+	switch e.errorType {
+	case transparentWrapper:
+		return true
+	case stackTraceWrapper:
+		// as opposed to an adoption of a non-errorx error
+		return Cast(e.cause) != nil
+	default:
+		return false
+	}
+}
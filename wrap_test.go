@@ -3,6 +3,7 @@ package errorx
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -66,3 +67,144 @@ func TestDecorateMany(t *testing.T) {
 		require.NotEqual(t, testTypeBar2, err.(*Error).Type())
 	})
 }
+
+func TestAdopt(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		require.Nil(t, Adopt(nil))
+	})
+
+	t.Run("Errorx", func(t *testing.T) {
+		err := testType.New("test")
+		require.Equal(t, err, Adopt(err))
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		err := Adopt(errors.New("bad"))
+		require.Equal(t, "bad", err.Error())
+		require.False(t, IsOfType(err, testType))
+		require.False(t, IsNotFound(err))
+	})
+}
+
+func TestWrapf(t *testing.T) {
+	t.Run("NoError", func(t *testing.T) {
+		require.NoError(t, funcWithDeferredWrap(0))
+	})
+
+	t.Run("Errorx", func(t *testing.T) {
+		err := funcWithDeferredWrap(1)
+		require.Error(t, err)
+		require.Equal(t, "in funcWithDeferredWrap 1, cause: foo.bar", err.Error())
+		require.True(t, IsOfType(err, testType))
+
+		output := fmt.Sprintf("%+v", err)
+		require.Contains(t, output, "createErrorFuncInStackTrace", output)
+	})
+
+	t.Run("Raw", func(t *testing.T) {
+		err := funcWithDeferredWrap(2)
+		require.Error(t, err)
+		require.Equal(t, "in funcWithDeferredWrap 2, cause: raw", err.Error())
+
+		output := fmt.Sprintf("%+v", err)
+		require.NotContains(t, output, "Wrapf()", output)
+		require.Contains(t, output, "funcWithDeferredWrap", output)
+		require.Contains(t, output, "TestWrapf", output)
+	})
+}
+
+func funcWithDeferredWrap(path int) (err error) {
+	defer Wrapf(&err, "in funcWithDeferredWrap %d", path)
+
+	switch path {
+	case 1:
+		return createErrorFuncInStackTrace(testType)
+	case 2:
+		return errors.New("raw")
+	default:
+		return nil
+	}
+}
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("Layers", func(t *testing.T) {
+		err := checkpointHandler()
+		require.Equal(t, "db failure", err.Error())
+
+		// each checkpoint starts a stack trace of its own, from the innermost to the outermost one
+		levels := strings.Split(fmt.Sprintf("%+v", err), "\n ---------------------------------- ")
+		require.Len(t, levels, 3)
+		require.True(t, strings.HasPrefix(levels[0], "db failure\n at github.com/joomcode/errorx.checkpointHandler()"), levels[0])
+		require.True(t, strings.HasPrefix(levels[1], "\n at github.com/joomcode/errorx.checkpointService()"), levels[1])
+		require.True(t, strings.HasPrefix(levels[2], "\n at github.com/joomcode/errorx.checkpointRepository()"), levels[2])
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		err := Cast(checkpointHandler())
+		require.Equal(t, fmt.Sprintf("%+v", err), fmt.Sprintf("%+v", err.Clone()))
+
+		levels := strings.Split(fmt.Sprintf("%+v", err.Clone()), "\n ---------------------------------- ")
+		require.Len(t, levels, 3)
+		require.True(t, strings.HasPrefix(levels[0], "db failure\n at github.com/joomcode/errorx.checkpointHandler()"), levels[0])
+	})
+
+	t.Run("Typed", func(t *testing.T) {
+		err := Checkpoint(NewDuplicate("key"))
+		require.True(t, IsOfType(err, DuplicateEntry))
+		require.True(t, IsDuplicate(err))
+
+		key, ok := ConflictKey(err)
+		require.True(t, ok)
+		require.Equal(t, "key", key)
+	})
+
+	t.Run("Nil", func(t *testing.T) {
+		require.NoError(t, Checkpoint(nil))
+	})
+}
+
+func checkpointRepository() error {
+	return Checkpoint(errors.New("db failure"))
+}
+
+func checkpointService() error {
+	return Checkpoint(checkpointRepository())
+}
+
+func checkpointHandler() error {
+	return Checkpoint(checkpointService())
+}
+
+func TestWrapDepth(t *testing.T) {
+	t.Run("Decorated", func(t *testing.T) {
+		err := testType.New("test")
+		require.Equal(t, 0, err.WrapDepth())
+
+		for i := 0; i < 5; i++ {
+			err = Decorate(err, "decorated %d", i)
+		}
+		require.Equal(t, 5, err.WrapDepth())
+	})
+
+	t.Run("Mixed", func(t *testing.T) {
+		err := Decorate(testType.Wrap(Decorate(errors.New("test"), "decorated"), "wrapped"), "decorated")
+		require.Equal(t, 2, err.WrapDepth())
+		require.Equal(t, 4, Cast(Checkpoint(EnhanceStackTrace(err, "enhanced"))).WrapDepth())
+		require.Equal(t, 0, Adopt(errors.New("test")).WrapDepth())
+		require.Equal(t, 0, Cast(Checkpoint(errors.New("test"))).WrapDepth())
+	})
+
+	t.Run("MaxWrapDepth", func(t *testing.T) {
+		var exceeded []string
+		SetMaxWrapDepth(3, func(err *Error) { exceeded = append(exceeded, err.Message()) })
+		defer SetMaxWrapDepth(0, nil)
+
+		err := testType.New("test")
+		for i := 0; i < 5; i++ {
+			err = Decorate(err, "decorated %d", i)
+		}
+		_ = testType.Wrap(err, "wrapped")
+
+		require.Equal(t, []string{"decorated 3", "decorated 4"}, exceeded)
+	})
+}